@@ -22,14 +22,13 @@ func main() {
 		}
 	}()
 
-	modelInfo, err := m.GetModelInfo(context.Background(), alias, nil)
+	cfg, err := m.OpenAIConfig(context.Background(), alias, nil)
 	if err != nil {
-		panic(fmt.Sprintf("Error getting model info: %v", err))
+		panic(fmt.Sprintf("Error getting OpenAI configuration: %v", err))
 	}
 
-	baseURL := m.Endpoint().String()
-	fmt.Printf("Using Foundry Local endpoint at %s\n", baseURL)
-	client := openai.NewClient(option.WithBaseURL(baseURL), option.WithAPIKey(m.ApiKey))
+	fmt.Printf("Using Foundry Local endpoint at %s\n", cfg.BaseURL)
+	client := openai.NewClient(option.WithBaseURL(cfg.BaseURL), option.WithAPIKey(cfg.APIKey))
 
 	question := "Write me a haiku"
 
@@ -40,7 +39,7 @@ func main() {
 		Messages: []openai.ChatCompletionMessageParamUnion{
 			openai.UserMessage(question),
 		},
-		Model: modelInfo.ID,
+		Model: cfg.Model,
 		Seed:  openai.Int(0),
 	})
 
@@ -2,9 +2,6 @@ module github.com/joergjo/go-foundry-local/examples/chat-completion-streaming
 
 go 1.24.4
 
-require (
-	github.com/joergjo/go-foundry-local/foundrylocal v0.0.0-20250922092026-a515b2dad948
-	github.com/tmaxmax/go-sse v0.11.0
-)
+require github.com/joergjo/go-foundry-local/foundrylocal v0.0.0-20250922092026-a515b2dad948
 
 replace github.com/joergjo/go-foundry-local/foundrylocal => ../../foundrylocal
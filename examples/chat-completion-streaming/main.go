@@ -1,40 +1,13 @@
 package main
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
-	"strings"
 	"time"
 
 	"github.com/joergjo/go-foundry-local/foundrylocal"
-	"github.com/tmaxmax/go-sse"
 )
 
-// chunk represents an OpenAI chat completion chunk, reduced to only those properties
-// required for this demo. See https://platform.openai.com/docs/api-reference/chat-streaming.
-type chunk struct {
-	Choices []struct {
-		Delta struct {
-			Content string `json:"content"`
-		} `json:"delta"`
-		FinishReason string `json:"finish_reason,omitzero"`
-	} `json:"choices"`
-}
-
-// completionRequest represents a minimized JSON structure for an OpenAI chat completion request.
-// See https://learn.microsoft.com/en-us/azure/ai-foundry/foundry-local/reference/reference-rest#post-v1chatcompletions.
-type completionRequest struct {
-	Model    string `json:"model"`
-	Messages []struct {
-		Role    string `json:"role"`
-		Content string `json:"content"`
-	} `json:"messages"`
-	Stream bool `json:"stream,omitzero"`
-}
-
 func main() {
 	alias := "qwen2.5-1.5b"
 
@@ -49,71 +22,37 @@ func main() {
 		}
 	}()
 
-	// Get model information to retrieve the model ID.
-	modelInfo, err := m.GetModelInfo(context.Background(), alias, nil)
-	if err != nil {
-		panic(fmt.Sprintf("Error getting model info: %v", err))
-	}
-
-	// Resolve the OpenAI endpoint.
-	baseURL := m.Endpoint().JoinPath("chat", "completions").String()
-	fmt.Printf("Using Foundry Local endpoint at %s\n", baseURL)
+	fmt.Printf("Using Foundry Local endpoint at %s\n", m.Endpoint())
 
-	// Prepare the chat completion request.
-	completion := completionRequest{
-		Model: modelInfo.ID,
-		Messages: []struct {
-			Role    string `json:"role"`
-			Content string `json:"content"`
-		}{
-			{
-				Role:    "user",
-				Content: "Write me a haiku",
-			},
+	// Prepare the chat completion request. The alias is resolved to the loaded model.
+	req := foundrylocal.ChatRequest{
+		Model: alias,
+		Messages: []foundrylocal.ChatMessage{
+			foundrylocal.UserMessage("Write me a haiku"),
 		},
-		Stream: true, // Enable streaming
-	}
-	payload, err := json.Marshal(completion)
-	if err != nil {
-		panic(err)
 	}
 
 	// Create a context with a timeout for the request.
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*2)
 	defer cancel()
 
-	// Make the HTTP request to the Foundry Local service.
-	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, baseURL, bytes.NewBuffer(payload))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+m.ApiKey)
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		panic(err)
-	}
-	defer resp.Body.Close()
-
-	// Process the SSE stream response.
-	var chunkData chunk
-	for ev, err := range sse.Read(resp.Body, nil) {
+	// Process the streamed response. ChatStream parses the Server-Sent Events stream
+	// and reports a finish reason even if Foundry Local omits it.
+	// https://github.com/microsoft/Foundry-Local/issues/299
+	var acc foundrylocal.ChatAccumulator
+	for chunk, err := range m.ChatStream(ctx, req) {
 		if err != nil {
-			fmt.Printf("Error reading SSE stream: %v\n", err)
+			fmt.Printf("\nError reading stream: %v\n", err)
 			break
 		}
-		if err := json.NewDecoder(strings.NewReader(ev.Data)).Decode(&chunkData); err != nil {
-			// Fix for Foundry local issue where finish_reason is not set.
-			// https://github.com/microsoft/Foundry-Local/issues/299
-			// This is a poor workaround, but serves demo purposes.
-			if ev.Data == "[DONE]" {
-				break
-			}
-			fmt.Printf("\nError decoding JSON %q: %v\n", ev.Data, err)
-			continue // skip this chunk if there's an error
-		}
-		if chunkData.Choices[0].FinishReason == "stop" {
-			break // stop processing if we hit the end of the response
+		acc.Add(chunk)
+		for _, choice := range chunk.Choices {
+			fmt.Print(choice.Delta.Content)
 		}
-		fmt.Print(chunkData.Choices[0].Delta.Content)
 	}
 	fmt.Println()
+
+	if resp := acc.Response(); len(resp.Choices) > 0 {
+		fmt.Printf("Finish reason: %s\n", resp.Choices[0].FinishReason)
+	}
 }
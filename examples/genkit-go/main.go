@@ -25,30 +25,29 @@ func main() {
 		}
 	}()
 
-	modelInfo, err := m.GetModelInfo(context.Background(), alias, nil)
+	cfg, err := m.OpenAIConfig(context.Background(), alias, nil)
 	if err != nil {
-		panic(fmt.Sprintf("Error getting model info: %v", err))
+		panic(fmt.Sprintf("Error getting OpenAI configuration: %v", err))
 	}
 
-	baseURL := m.Endpoint().String()
-	fmt.Printf("Using Foundry Local endpoint at %s\n", baseURL)
+	fmt.Printf("Using Foundry Local endpoint at %s\n", cfg.BaseURL)
 
 	ctx := context.Background()
 
 	// To use GenKit Go, we need to set its OpenAI plugin's base URL to Foundry Local's OpenAI endpoint.
 	openAI := &oai.OpenAI{
-		APIKey: m.ApiKey,
+		APIKey: cfg.APIKey,
 		Opts: []option.RequestOption{
-			option.WithBaseURL(m.Endpoint().String()),
+			option.WithBaseURL(cfg.BaseURL),
 		},
 	}
 	g := genkit.Init(ctx, genkit.WithPlugins(openAI))
 	// Specify the model and its capabilities
-	model := openAI.DefineModel(modelInfo.ID, ai.ModelOptions{
+	model := openAI.DefineModel(cfg.Model, ai.ModelOptions{
 		Supports: &compat_oai.BasicText,
 	})
 	// Note that the following code will also work
-	// model := openAI.Model(g, modelInfo.ID)
+	// model := openAI.Model(g, cfg.Model)
 	// because it registers our model dynamically in Genkit. But dynamically registered
 	// OpenAI compatible models are assumed to be multi-modal, which is not true for our
 	// model used in this sample. We don't use any multi-modal capabilities so the sample
@@ -0,0 +1,256 @@
+package foundrylocal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Role identifies the author of a chat message.
+type Role string
+
+const (
+	// RoleSystem is the role of instructions that guide the model's behavior.
+	RoleSystem Role = "system"
+	// RoleUser is the role of messages written by the user.
+	RoleUser Role = "user"
+	// RoleAssistant is the role of messages generated by the model.
+	RoleAssistant Role = "assistant"
+	// RoleTool is the role of messages that contain the result of a tool call.
+	RoleTool Role = "tool"
+)
+
+// ChatMessage is a message of a chat conversation.
+type ChatMessage struct {
+	// Role is the author of the message.
+	Role Role `json:"role"`
+	// Content is the text of the message.
+	Content string `json:"content"`
+	// ToolCalls contains the tools the model requested to call in an assistant message.
+	ToolCalls []ToolCall `json:"tool_calls,omitzero"`
+	// ToolCallID is the ID of the tool call a tool message responds to.
+	ToolCallID string `json:"tool_call_id,omitzero"`
+}
+
+// SystemMessage returns a ChatMessage with RoleSystem.
+func SystemMessage(content string) ChatMessage {
+	return ChatMessage{Role: RoleSystem, Content: content}
+}
+
+// UserMessage returns a ChatMessage with RoleUser.
+func UserMessage(content string) ChatMessage {
+	return ChatMessage{Role: RoleUser, Content: content}
+}
+
+// AssistantMessage returns a ChatMessage with RoleAssistant.
+func AssistantMessage(content string) ChatMessage {
+	return ChatMessage{Role: RoleAssistant, Content: content}
+}
+
+// ToolMessage returns a ChatMessage with RoleTool that contains the result of
+// the tool call with toolCallID.
+func ToolMessage(toolCallID, content string) ChatMessage {
+	return ChatMessage{Role: RoleTool, Content: content, ToolCallID: toolCallID}
+}
+
+// ChatRequest is a request for a chat completion. Optional sampling parameters
+// are pointers, so that zero values such as a temperature of 0 can be sent.
+// Use Ptr to set them.
+type ChatRequest struct {
+	// Model is the alias or ID of the model. Aliases are resolved like in
+	// GetModelInfo.
+	Model string `json:"model"`
+	// Messages contains the conversation so far.
+	Messages []ChatMessage `json:"messages"`
+	// MaxTokens limits the number of tokens to generate. It is validated against
+	// ModelInfo.MaxOutputTokens, see WithValidationMode.
+	MaxTokens *int `json:"max_tokens,omitempty"`
+	// Temperature controls the randomness of the output, between 0 and 2.
+	Temperature *float64 `json:"temperature,omitempty"`
+	// TopP enables nucleus sampling with the given probability mass.
+	TopP *float64 `json:"top_p,omitempty"`
+	// PresencePenalty penalizes tokens that already appeared, between -2 and 2.
+	PresencePenalty *float64 `json:"presence_penalty,omitempty"`
+	// FrequencyPenalty penalizes tokens by their frequency so far, between -2 and 2.
+	FrequencyPenalty *float64 `json:"frequency_penalty,omitempty"`
+	// Seed makes sampling deterministic on a best-effort basis.
+	Seed *int64 `json:"seed,omitempty"`
+	// Stop contains up to four sequences that end generation.
+	Stop []string `json:"stop,omitzero"`
+	// Tools contains the tools the model may call. The model must support tool
+	// calling, see ModelInfo.SupportsToolCalling.
+	Tools []Tool `json:"tools,omitzero"`
+	// ToolChoice controls whether the model calls tools. If empty, the model
+	// decides.
+	ToolChoice ToolChoice `json:"tool_choice,omitzero"`
+	// ResponseFormat constrains the format of the generated message. Not all
+	// runtimes and models support it; see ChatJSON for a portable alternative.
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+}
+
+// ResponseFormat constrains the format of a generated message.
+type ResponseFormat struct {
+	// Type is "text", "json_object", or "json_schema".
+	Type string `json:"type"`
+	// JSONSchema describes the expected JSON if Type is "json_schema".
+	JSONSchema *ResponseSchema `json:"json_schema,omitempty"`
+}
+
+// ResponseSchema is a named JSON Schema for a ResponseFormat of type "json_schema".
+type ResponseSchema struct {
+	// Name identifies the schema.
+	Name string `json:"name"`
+	// Schema is the JSON Schema the message must match.
+	Schema *JSONSchema `json:"schema"`
+	// Strict requests that the runtime enforces the schema exactly.
+	Strict bool `json:"strict,omitzero"`
+}
+
+// FinishReason describes why the model stopped generating.
+type FinishReason string
+
+const (
+	// FinishReasonStop indicates that the model finished its response or hit a stop sequence.
+	FinishReasonStop FinishReason = "stop"
+	// FinishReasonLength indicates that the response was cut off by MaxTokens or the context window.
+	FinishReasonLength FinishReason = "length"
+	// FinishReasonContentFilter indicates that content was omitted by a content filter.
+	FinishReasonContentFilter FinishReason = "content_filter"
+	// FinishReasonToolCalls indicates that the model requested tool calls.
+	FinishReasonToolCalls FinishReason = "tool_calls"
+)
+
+// ChatChoice is a completion choice of a ChatResponse.
+type ChatChoice struct {
+	// Index is the index of the choice.
+	Index int `json:"index"`
+	// Message is the generated message.
+	Message ChatMessage `json:"message"`
+	// FinishReason describes why generation stopped.
+	FinishReason FinishReason `json:"finish_reason"`
+}
+
+// Usage reports the number of tokens used by a completion.
+type Usage struct {
+	// PromptTokens is the number of tokens in the prompt.
+	PromptTokens int `json:"prompt_tokens"`
+	// CompletionTokens is the number of generated tokens.
+	CompletionTokens int `json:"completion_tokens"`
+	// TotalTokens is the sum of prompt and completion tokens.
+	TotalTokens int `json:"total_tokens"`
+}
+
+// ChatResponse is the response to a ChatRequest.
+type ChatResponse struct {
+	// ID is the unique identifier of the completion.
+	ID string `json:"id"`
+	// Created is the Unix time in seconds when the completion was created.
+	Created int64 `json:"created"`
+	// Model is the ID of the model that generated the completion.
+	Model string `json:"model"`
+	// Choices contains the generated choices.
+	Choices []ChatChoice `json:"choices"`
+	// Usage reports the number of tokens used.
+	Usage Usage `json:"usage"`
+}
+
+// Message returns the message of the first choice, or the zero value if the
+// response has no choices.
+func (r ChatResponse) Message() ChatMessage {
+	if len(r.Choices) == 0 {
+		return ChatMessage{}
+	}
+	return r.Choices[0].Message
+}
+
+// Ptr returns a pointer to v. It is a convenience for setting optional fields
+// such as ChatRequest.Temperature.
+//
+// Example:
+//
+//	req := foundrylocal.ChatRequest{Model: "phi-4-mini", Temperature: foundrylocal.Ptr(0.2)}
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// Chat sends a chat completion request to the model's OpenAI-compatible endpoint
+// and returns the response. The model must be loaded, and the request is
+// authenticated with the Manager's ApiKey. The service is started if it is not
+// running. Only the standard library is used, so no OpenAI SDK is required.
+//
+// Example:
+//
+//	resp, err := manager.Chat(ctx, foundrylocal.ChatRequest{
+//		Model: "qwen2.5-0.5b",
+//		Messages: []foundrylocal.ChatMessage{
+//			foundrylocal.SystemMessage("You are a helpful assistant."),
+//			foundrylocal.UserMessage("Write me a haiku"),
+//		},
+//		Temperature: foundrylocal.Ptr(0.7),
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(resp.Message().Content)
+func (m *Manager) Chat(ctx context.Context, req ChatRequest) (ChatResponse, error) {
+	resp, err := m.sendChat(ctx, "Chat", req, false)
+	if err != nil {
+		return ChatResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	var result ChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return ChatResponse{}, fmt.Errorf("failed to decode chat completion: %w", err)
+	}
+	return result, nil
+}
+
+// sendChat resolves the model of req and posts it to the chat completions endpoint.
+func (m *Manager) sendChat(ctx context.Context, name string, req ChatRequest, stream bool) (*http.Response, error) {
+	if req.Model == "" {
+		return nil, errors.New("chat request has no model")
+	}
+	modelInfo, err := m.inferenceModel(ctx, req.Model)
+	if err != nil {
+		return nil, err
+	}
+	req.Model = modelInfo.ID
+	if req.MaxTokens, err = m.validateRequest(ctx, name, modelInfo, req.MaxTokens, len(req.Tools) > 0); err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(struct {
+		ChatRequest
+		Stream bool `json:"stream,omitzero"`
+	}{req, stream})
+	if err != nil {
+		return nil, err
+	}
+	return m.postInference(ctx, name, []string{"v1", "chat", "completions"}, modelInfo.ID, body)
+}
+
+// inferenceModel starts the service if needed and resolves the model of an
+// inference request.
+func (m *Manager) inferenceModel(ctx context.Context, aliasOrModelID string) (ModelInfo, error) {
+	if err := m.StartService(ctx); err != nil {
+		return ModelInfo{}, err
+	}
+	return m.GetModelInfo(ctx, aliasOrModelID, nil)
+}
+
+// postInference posts body to the OpenAI-compatible endpoint at path, authenticated
+// with the Manager's ApiKey.
+func (m *Manager) postInference(ctx context.Context, name string, path []string, modelID string, body []byte) (*http.Response, error) {
+	return m.do(ctx, operation{
+		name:    name,
+		method:  http.MethodPost,
+		path:    path,
+		body:    body,
+		header:  http.Header{"Authorization": {"Bearer " + m.ApiKey}},
+		class:   classInference,
+		modelID: modelID,
+	})
+}
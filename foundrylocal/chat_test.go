@@ -0,0 +1,199 @@
+package foundrylocal
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// mockChat returns a handler that serves the catalog and answers chat completion
+// requests with response, recording the decoded request body and headers.
+func mockChat(t *testing.T, status int, response string, got *map[string]any, header *http.Header) http.Handler {
+	t.Helper()
+	catalog := newHandler(mockCatalog(true))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			catalog.ServeHTTP(w, r)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read request body: %v", err)
+		}
+		if err := json.Unmarshal(body, got); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		*header = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(response))
+	})
+}
+
+// TestChat verifies the chat completion request sent to the service and the
+// decoding of its response.
+func TestChat(t *testing.T) {
+	const response = `{
+		"id": "chatcmpl-1",
+		"object": "chat.completion",
+		"created": 1730000000,
+		"model": "model-2-npu:2",
+		"choices": [{"index": 0, "message": {"role": "assistant", "content": "Autumn moonlight"}, "finish_reason": "length"}],
+		"usage": {"prompt_tokens": 12, "completion_tokens": 3, "total_tokens": 15}
+	}`
+
+	tests := []struct {
+		name        string
+		req         ChatRequest
+		wantFields  map[string]any
+		wantMissing []string
+	}{
+		{
+			name: "defaults_omitted",
+			req: ChatRequest{
+				Model:    "model-2",
+				Messages: []ChatMessage{UserMessage("Write me a haiku")},
+			},
+			wantFields:  map[string]any{"model": "model-2-npu:2"},
+			wantMissing: []string{"temperature", "max_tokens", "top_p", "seed", "stop", "stream"},
+		},
+		{
+			name: "sampling_parameters",
+			req: ChatRequest{
+				Model:       "model-2-npu:2",
+				Messages:    []ChatMessage{SystemMessage("Be brief."), UserMessage("Write me a haiku")},
+				MaxTokens:   Ptr(3),
+				Temperature: Ptr(0.0),
+				TopP:        Ptr(0.9),
+				Seed:        Ptr[int64](42),
+				Stop:        []string{"\n\n"},
+			},
+			wantFields: map[string]any{
+				"model":       "model-2-npu:2",
+				"max_tokens":  3.0,
+				"temperature": 0.0,
+				"top_p":       0.9,
+				"seed":        42.0,
+			},
+			wantMissing: []string{"presence_penalty", "frequency_penalty", "stream"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				body   map[string]any
+				header http.Header
+			)
+			srv := httptest.NewServer(mockChat(t, http.StatusOK, response, &body, &header))
+			defer srv.Close()
+			serviceURL, err := url.Parse(srv.URL)
+			if err != nil {
+				t.Fatalf("failed to parse service URL: %v", err)
+			}
+
+			m := NewManager(WithHTTPClient(srv.Client()))
+			m.serviceURL = serviceURL
+			m.ApiKey = "test-key"
+
+			resp, err := m.Chat(t.Context(), tc.req)
+			if err != nil {
+				t.Fatalf("failed to chat: %v", err)
+			}
+
+			if got, want := header.Get("Authorization"), "Bearer test-key"; got != want {
+				t.Errorf("got Authorization %q, want %q", got, want)
+			}
+			for key, want := range tc.wantFields {
+				if got := body[key]; got != want {
+					t.Errorf("got %s %v, want %v", key, got, want)
+				}
+			}
+			for _, key := range tc.wantMissing {
+				if got, ok := body[key]; ok {
+					t.Errorf("got %s %v, want field omitted", key, got)
+				}
+			}
+			if got, want := len(body["messages"].([]any)), len(tc.req.Messages); got != want {
+				t.Errorf("got %d messages, want %d", got, want)
+			}
+
+			if got, want := resp.Message(), AssistantMessage("Autumn moonlight"); !reflect.DeepEqual(got, want) {
+				t.Errorf("got message %+v, want %+v", got, want)
+			}
+			if got, want := resp.Choices[0].FinishReason, FinishReasonLength; got != want {
+				t.Errorf("got finish reason %q, want %q", got, want)
+			}
+			if got, want := resp.Usage, (Usage{PromptTokens: 12, CompletionTokens: 3, TotalTokens: 15}); got != want {
+				t.Errorf("got usage %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+// TestChatErrors verifies that Chat rejects invalid requests and surfaces
+// service errors as APIError.
+func TestChatErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        ChatRequest
+		wantErr    error
+		wantStatus int
+	}{
+		{
+			name:    "unknown_model",
+			req:     ChatRequest{Model: "model-42", Messages: []ChatMessage{UserMessage("Hi")}},
+			wantErr: ErrModelNotInCatalog,
+		},
+		{
+			name:       "model_not_loaded",
+			req:        ChatRequest{Model: "model-2", Messages: []ChatMessage{UserMessage("Hi")}},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				body   map[string]any
+				header http.Header
+			)
+			srv := httptest.NewServer(mockChat(t, http.StatusBadRequest, `{"error": "model not loaded"}`, &body, &header))
+			defer srv.Close()
+			serviceURL, err := url.Parse(srv.URL)
+			if err != nil {
+				t.Fatalf("failed to parse service URL: %v", err)
+			}
+
+			m := NewManager(WithHTTPClient(srv.Client()))
+			m.serviceURL = serviceURL
+
+			_, err = m.Chat(t.Context(), tc.req)
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tc.wantErr)
+			}
+			if tc.wantStatus != 0 {
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) {
+					t.Fatalf("got error %v, want *APIError", err)
+				}
+				if got, want := apiErr.StatusCode, tc.wantStatus; got != want {
+					t.Errorf("got status %d, want %d", got, want)
+				}
+				if got, want := apiErr.Op, "Chat"; got != want {
+					t.Errorf("got op %q, want %q", got, want)
+				}
+			}
+		})
+	}
+
+	m := NewManager()
+	if _, err := m.Chat(t.Context(), ChatRequest{}); err == nil {
+		t.Errorf("got nil error for request without model, want non-nil error")
+	}
+}
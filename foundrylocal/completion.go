@@ -0,0 +1,177 @@
+package foundrylocal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// CompletionRequest is a request for a raw text completion. Unlike a ChatRequest,
+// the prompt is sent as is, so it must already be in the model's prompt format.
+// See PromptTemplate.Render.
+type CompletionRequest struct {
+	// Model is the ID of the model.
+	Model string `json:"model"`
+	// Prompt is the text to complete.
+	Prompt string `json:"prompt"`
+	// MaxTokens limits the number of tokens to generate.
+	MaxTokens *int `json:"max_tokens,omitempty"`
+	// Temperature controls the randomness of the output, between 0 and 2.
+	Temperature *float64 `json:"temperature,omitempty"`
+	// TopP enables nucleus sampling with the given probability mass.
+	TopP *float64 `json:"top_p,omitempty"`
+	// Seed makes sampling deterministic on a best-effort basis.
+	Seed *int64 `json:"seed,omitempty"`
+	// Stop contains up to four sequences that end generation.
+	Stop []string `json:"stop,omitzero"`
+}
+
+// CompletionChoice is a completion choice of a CompletionResponse.
+type CompletionChoice struct {
+	// Index is the index of the choice.
+	Index int `json:"index"`
+	// Text is the generated text.
+	Text string `json:"text"`
+	// FinishReason describes why generation stopped.
+	FinishReason FinishReason `json:"finish_reason"`
+}
+
+// CompletionResponse is the response to a CompletionRequest.
+type CompletionResponse struct {
+	// ID is the unique identifier of the completion.
+	ID string `json:"id"`
+	// Created is the Unix time in seconds when the completion was created.
+	Created int64 `json:"created"`
+	// Model is the ID of the model that generated the completion.
+	Model string `json:"model"`
+	// Choices contains the generated choices.
+	Choices []CompletionChoice `json:"choices"`
+	// Usage reports the number of tokens used.
+	Usage Usage `json:"usage"`
+}
+
+// Text returns the text of the first choice, or an empty string if the response
+// has no choices.
+func (r CompletionResponse) Text() string {
+	if len(r.Choices) == 0 {
+		return ""
+	}
+	return r.Choices[0].Text
+}
+
+// CompletionOption sets optional parameters of a CompletionRequest.
+type CompletionOption func(*CompletionRequest)
+
+// WithMaxTokens limits the number of tokens Complete generates.
+//
+// Example:
+//
+//	resp, err := manager.Complete(ctx, "phi-3.5-mini", prompt, foundrylocal.WithMaxTokens(64))
+func WithMaxTokens(tokens int) CompletionOption {
+	return func(req *CompletionRequest) {
+		req.MaxTokens = &tokens
+	}
+}
+
+// WithTemperature sets the sampling temperature of Complete, between 0 and 2.
+//
+// Example:
+//
+//	resp, err := manager.Complete(ctx, "phi-3.5-mini", prompt, foundrylocal.WithTemperature(0))
+func WithTemperature(temperature float64) CompletionOption {
+	return func(req *CompletionRequest) {
+		req.Temperature = &temperature
+	}
+}
+
+// WithTopP enables nucleus sampling with the given probability mass for Complete.
+//
+// Example:
+//
+//	resp, err := manager.Complete(ctx, "phi-3.5-mini", prompt, foundrylocal.WithTopP(0.9))
+func WithTopP(topP float64) CompletionOption {
+	return func(req *CompletionRequest) {
+		req.TopP = &topP
+	}
+}
+
+// WithSeed makes the sampling of Complete deterministic on a best-effort basis.
+//
+// Example:
+//
+//	resp, err := manager.Complete(ctx, "phi-3.5-mini", prompt, foundrylocal.WithSeed(42))
+func WithSeed(seed int64) CompletionOption {
+	return func(req *CompletionRequest) {
+		req.Seed = &seed
+	}
+}
+
+// WithStop sets sequences that end the generation of Complete.
+//
+// Example:
+//
+//	resp, err := manager.Complete(ctx, "phi-3.5-mini", prompt, foundrylocal.WithStop("<|end|>"))
+func WithStop(sequences ...string) CompletionOption {
+	return func(req *CompletionRequest) {
+		req.Stop = sequences
+	}
+}
+
+// Complete sends prompt to the model's OpenAI-compatible completions endpoint and
+// returns the generated continuation. The prompt is not formatted, which allows raw
+// completion, prefilling, and few-shot prompts in the model's exact format. Use
+// PromptTemplate.Render to format a conversation. The model must be loaded, and the
+// service is started if it is not running.
+//
+// Example:
+//
+//	info, err := manager.GetModelInfo(ctx, "phi-3.5-mini", nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	prompt, err := info.PromptTemplate.Render([]foundrylocal.ChatMessage{
+//		foundrylocal.UserMessage("List three colors as JSON."),
+//		foundrylocal.AssistantMessage(`["`),
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	resp, err := manager.Complete(ctx, info.ID, prompt, foundrylocal.WithMaxTokens(32))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(`["` + resp.Text())
+func (m *Manager) Complete(ctx context.Context, model, prompt string, opts ...CompletionOption) (CompletionResponse, error) {
+	if model == "" {
+		return CompletionResponse{}, errors.New("completion request has no model")
+	}
+	modelInfo, err := m.inferenceModel(ctx, model)
+	if err != nil {
+		return CompletionResponse{}, err
+	}
+
+	req := CompletionRequest{Model: modelInfo.ID, Prompt: prompt}
+	for _, opt := range opts {
+		opt(&req)
+	}
+	if req.MaxTokens, err = m.validateRequest(ctx, "Complete", modelInfo, req.MaxTokens, false); err != nil {
+		return CompletionResponse{}, err
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return CompletionResponse{}, err
+	}
+
+	resp, err := m.postInference(ctx, "Complete", []string{"v1", "completions"}, modelInfo.ID, body)
+	if err != nil {
+		return CompletionResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	var result CompletionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return CompletionResponse{}, fmt.Errorf("failed to decode completion: %w", err)
+	}
+	return result, nil
+}
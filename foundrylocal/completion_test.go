@@ -0,0 +1,81 @@
+package foundrylocal
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestComplete verifies the completion request sent to the service and the
+// decoding of its response.
+func TestComplete(t *testing.T) {
+	const response = `{
+		"id": "cmpl-1",
+		"object": "text_completion",
+		"created": 1730000000,
+		"model": "model-2-npu:2",
+		"choices": [{"index": 0, "text": "red\", \"green\"]", "finish_reason": "stop"}],
+		"usage": {"prompt_tokens": 12, "completion_tokens": 5, "total_tokens": 17}
+	}`
+
+	var (
+		body   map[string]any
+		header http.Header
+	)
+	catalog := newHandler(mockCatalog(true))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/completions" {
+			catalog.ServeHTTP(w, r)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		header = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}))
+	defer srv.Close()
+	serviceURL, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to parse service URL: %v", err)
+	}
+
+	m := NewManager(WithHTTPClient(srv.Client()))
+	m.serviceURL = serviceURL
+	m.ApiKey = "secret"
+
+	resp, err := m.Complete(t.Context(), "model-2", "<|user|>Colors?<|end|><|assistant|>[\"",
+		WithMaxTokens(16), WithTemperature(0), WithStop("<|end|>"))
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	wantBody := map[string]any{
+		"model":       "model-2-npu:2",
+		"prompt":      "<|user|>Colors?<|end|><|assistant|>[\"",
+		"max_tokens":  16.0,
+		"temperature": 0.0,
+		"stop":        []any{"<|end|>"},
+	}
+	if !reflect.DeepEqual(body, wantBody) {
+		t.Errorf("got request body %v, want %v", body, wantBody)
+	}
+	if got, want := header.Get("Authorization"), "Bearer secret"; got != want {
+		t.Errorf("got Authorization %q, want %q", got, want)
+	}
+	if got, want := resp.Text(), `red", "green"]`; got != want {
+		t.Errorf("got text %q, want %q", got, want)
+	}
+	if got, want := resp.Choices[0].FinishReason, FinishReasonStop; got != want {
+		t.Errorf("got finish reason %q, want %q", got, want)
+	}
+	if got, want := resp.Usage, (Usage{PromptTokens: 12, CompletionTokens: 5, TotalTokens: 17}); got != want {
+		t.Errorf("got usage %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,123 @@
+package foundrylocal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ServiceConfig contains the settings of the Foundry Local service.
+type ServiceConfig struct {
+	// Port is the port the service listens on.
+	Port int
+	// CacheDir is the directory where the service stores downloaded models.
+	CacheDir string
+	// DefaultTTL is the default time a loaded model stays in memory when it is not used.
+	DefaultTTL time.Duration
+}
+
+// Validate checks that the configuration contains valid settings. Zero values are
+// valid and mean "leave unchanged" when passed to SetServiceConfig. Validate returns
+// an error wrapping ErrInvalidServiceConfig that describes each invalid setting.
+func (c ServiceConfig) Validate() error {
+	var errs []error
+	if c.Port < 0 || c.Port > 65535 {
+		errs = append(errs, fmt.Errorf("port %d is out of range 1-65535", c.Port))
+	}
+	if c.CacheDir != "" && !filepath.IsAbs(c.CacheDir) {
+		errs = append(errs, fmt.Errorf("cache directory %q is not an absolute path", c.CacheDir))
+	}
+	if c.DefaultTTL < 0 || c.DefaultTTL%time.Second != 0 {
+		errs = append(errs, fmt.Errorf("default TTL %s is not a non-negative whole number of seconds", c.DefaultTTL))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidServiceConfig, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ServiceConfig reads the Foundry Local service configuration by running
+// 'foundry service set --show'. The Manager's ServiceLauncher must implement
+// CommandRunner; otherwise ErrCommandsNotSupported is returned.
+//
+// Example:
+//
+//	cfg, err := manager.ServiceConfig(ctx)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Printf("Service port: %d, cache: %s\n", cfg.Port, cfg.CacheDir)
+func (m *Manager) ServiceConfig(ctx context.Context) (ServiceConfig, error) {
+	out, err := m.invokeFoundry(ctx, "service", "set", "--show")
+	if err != nil {
+		return ServiceConfig{}, fmt.Errorf("failed to read service configuration: %w", err)
+	}
+
+	// The JSON document may be preceded or followed by status messages.
+	start, end := strings.Index(out, "{"), strings.LastIndex(out, "}")
+	if start == -1 || end < start {
+		return ServiceConfig{}, fmt.Errorf("no JSON object found in service configuration %q", out)
+	}
+
+	var doc struct {
+		ServiceSettings struct {
+			Port                      int    `json:"port"`
+			CacheDirectoryPath        string `json:"cacheDirectoryPath"`
+			DefaultSecondsForModelTTL int    `json:"defaultSecondsForModelTTL"`
+		} `json:"serviceSettings"`
+	}
+	if err := json.Unmarshal([]byte(out[start:end+1]), &doc); err != nil {
+		return ServiceConfig{}, err
+	}
+	return ServiceConfig{
+		Port:       doc.ServiceSettings.Port,
+		CacheDir:   doc.ServiceSettings.CacheDirectoryPath,
+		DefaultTTL: time.Duration(doc.ServiceSettings.DefaultSecondsForModelTTL) * time.Second,
+	}, nil
+}
+
+// SetServiceConfig changes the Foundry Local service configuration. Only non-zero
+// settings of cfg are applied; the configuration is validated before any change is
+// made. Port and TTL are set with 'foundry service set', the cache directory with
+// 'foundry cache cd'. Changes to the port take effect after the service restarts.
+// The Manager's ServiceLauncher must implement CommandRunner; otherwise
+// ErrCommandsNotSupported is returned.
+//
+// Example:
+//
+//	err := manager.SetServiceConfig(ctx, foundrylocal.ServiceConfig{
+//		Port:       5273,
+//		DefaultTTL: 30 * time.Minute,
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func (m *Manager) SetServiceConfig(ctx context.Context, cfg ServiceConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	var commands [][]string
+	if cfg.Port != 0 {
+		commands = append(commands, []string{"service", "set", "--port", strconv.Itoa(cfg.Port)})
+	}
+	if cfg.DefaultTTL != 0 {
+		commands = append(commands, []string{"service", "set", "--ttl", strconv.FormatInt(int64(cfg.DefaultTTL/time.Second), 10)})
+	}
+	if cfg.CacheDir != "" {
+		commands = append(commands, []string{"cache", "cd", cfg.CacheDir})
+	}
+
+	for _, args := range commands {
+		if out, err := m.invokeFoundry(ctx, args...); err != nil {
+			return fmt.Errorf("failed to run 'foundry %s': %w: %s", strings.Join(args, " "), err, strings.TrimSpace(out))
+		}
+		m.Logger.InfoContext(ctx, "updated Foundry service configuration", "command", strings.Join(args, " "))
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package foundrylocal
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestServiceConfig verifies ServiceConfig parses the output of
+// 'foundry service set --show' and requires a CommandRunner.
+func TestServiceConfig(t *testing.T) {
+	runner := &fakeRunner{
+		outputs: map[string]string{
+			"service set --show": `Current service configuration:
+{
+  "defaultLogLevel": 2,
+  "serviceSettings": {
+    "host": "localhost",
+    "port": 5273,
+    "cacheDirectoryPath": "/Users/me/.foundry/cache/models",
+    "defaultSecondsForModelTTL": 600
+  }
+}`,
+		},
+	}
+	m := NewManager(WithServiceLauncher(runner))
+
+	cfg, err := m.ServiceConfig(t.Context())
+	if err != nil {
+		t.Fatalf("failed to read service configuration: %v", err)
+	}
+	want := ServiceConfig{Port: 5273, CacheDir: "/Users/me/.foundry/cache/models", DefaultTTL: 10 * time.Minute}
+	if got := cfg; got != want {
+		t.Errorf("got service configuration %+v, want %+v", got, want)
+	}
+
+	m = NewManager(WithServiceLauncher(&fakeLauncher{}))
+	if _, err := m.ServiceConfig(t.Context()); !errors.Is(err, ErrCommandsNotSupported) {
+		t.Errorf("got error %v, want %v", err, ErrCommandsNotSupported)
+	}
+}
+
+// TestSetServiceConfig verifies SetServiceConfig validates the configuration
+// and runs one foundry command per changed setting.
+func TestSetServiceConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       ServiceConfig
+		wantCalls []string
+		err       error
+	}{
+		{
+			name: "set_all",
+			cfg:  ServiceConfig{Port: 5273, CacheDir: "/models", DefaultTTL: time.Hour},
+			wantCalls: []string{
+				"service set --port 5273",
+				"service set --ttl 3600",
+				"cache cd /models",
+			},
+		},
+		{
+			name:      "set_port_only",
+			cfg:       ServiceConfig{Port: 8080},
+			wantCalls: []string{"service set --port 8080"},
+		},
+		{
+			name: "invalid_port",
+			cfg:  ServiceConfig{Port: 70000},
+			err:  ErrInvalidServiceConfig,
+		},
+		{
+			name: "relative_cache_dir",
+			cfg:  ServiceConfig{CacheDir: "models"},
+			err:  ErrInvalidServiceConfig,
+		},
+		{
+			name: "fractional_ttl",
+			cfg:  ServiceConfig{DefaultTTL: 1500 * time.Millisecond},
+			err:  ErrInvalidServiceConfig,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := &fakeRunner{outputs: map[string]string{}}
+			for _, call := range tc.wantCalls {
+				runner.outputs[call] = "Configuration updated."
+			}
+			m := NewManager(WithServiceLauncher(runner))
+
+			err := m.SetServiceConfig(t.Context(), tc.cfg)
+			if got, want := err, tc.err; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+
+			var calls []string
+			for _, args := range runner.calls {
+				calls = append(calls, strings.Join(args, " "))
+			}
+			if got, want := calls, tc.wantCalls; !slices.Equal(got, want) {
+				t.Errorf("got commands %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package foundrylocal
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodySize limits how much of a response body is captured in an APIError.
+const maxErrorBodySize = 4096
+
+// APIError is returned when the Foundry Local service responds with a non-success
+// status code. It carries enough context to tell failures apart and to show the
+// runtime's own error message.
+//
+// Example:
+//
+//	_, err := manager.LoadModel(ctx, "qwen2.5-0.5b", nil)
+//	var apiErr *foundrylocal.APIError
+//	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+//		log.Printf("Foundry Local does not know the model: %s", apiErr.Body)
+//	}
+type APIError struct {
+	// Op is the name of the operation that failed, such as "LoadModel".
+	Op string
+	// URL is the URL of the failed request.
+	URL string
+	// StatusCode is the HTTP status code returned by the service.
+	StatusCode int
+	// Body contains the beginning of the response body, limited to 4 KiB.
+	Body string
+}
+
+// Error returns a description of the failed request including the response body.
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s: %s returned status %d", e.Op, e.URL, e.StatusCode)
+	if e.Body != "" {
+		msg += ": " + e.Body
+	}
+	return msg
+}
+
+// newAPIError creates an APIError for the non-success response resp of operation op.
+// It reads up to maxErrorBodySize bytes of the response body.
+func newAPIError(op string, resp *http.Response) *APIError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	return &APIError{
+		Op:         op,
+		URL:        resp.Request.URL.String(),
+		StatusCode: resp.StatusCode,
+		Body:       strings.TrimSpace(string(body)),
+	}
+}
+
+// Violation describes a field of a request that exceeds the limits of a model.
+type Violation struct {
+	// Field is the JSON name of the request field, such as "max_tokens".
+	Field string
+	// Message describes why the field is invalid.
+	Message string
+	// Err is the sentinel error for the violation, such as
+	// ErrToolCallingNotSupported, or nil if there is none.
+	Err error
+}
+
+// ValidationError is returned when a request to a model fails pre-flight
+// validation against the model's limits, before it is sent to the service.
+// See WithValidationMode.
+//
+// Example:
+//
+//	_, err := manager.Chat(ctx, req)
+//	if errors.Is(err, foundrylocal.ErrToolCallingNotSupported) {
+//		log.Print("Model cannot call tools")
+//	}
+//	var validationErr *foundrylocal.ValidationError
+//	if errors.As(err, &validationErr) {
+//		for _, v := range validationErr.Violations {
+//			log.Printf("%s: %s", v.Field, v.Message)
+//		}
+//	}
+type ValidationError struct {
+	// Op is the name of the operation that was rejected, such as "Chat".
+	Op string
+	// Model is the ID of the model the request was validated against.
+	Model string
+	// Violations lists each violated limit.
+	Violations []Violation
+}
+
+// Error returns a description of the rejected request listing all violations.
+func (e *ValidationError) Error() string {
+	violations := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		violations[i] = v.Field + ": " + v.Message
+	}
+	return fmt.Sprintf("%s: invalid request for model %s: %s", e.Op, e.Model, strings.Join(violations, "; "))
+}
+
+// Unwrap returns the sentinel errors of the violations, so that errors.Is matches,
+// for example, ErrToolCallingNotSupported.
+func (e *ValidationError) Unwrap() []error {
+	var errs []error
+	for _, v := range e.Violations {
+		if v.Err != nil {
+			errs = append(errs, v.Err)
+		}
+	}
+	return errs
+}
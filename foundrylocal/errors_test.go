@@ -0,0 +1,245 @@
+package foundrylocal
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+)
+
+// TestAPIError verifies non-success responses are reported as *APIError
+// carrying the operation, URL, status code, and response body.
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name       string
+		failPath   string
+		call       func(m *Manager) error
+		wantOp     string
+		wantStatus int
+	}{
+		{
+			name:     "list_catalog_models",
+			failPath: "/foundry/list",
+			call: func(m *Manager) error {
+				_, err := m.ListCatalogModels(t.Context())
+				return err
+			},
+			wantOp:     "ListCatalogModels",
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:     "list_cached_models",
+			failPath: "/openai/models",
+			call: func(m *Manager) error {
+				_, err := m.ListCachedModels(t.Context())
+				return err
+			},
+			wantOp:     "ListCachedModels",
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:     "load_model",
+			failPath: "/openai/load/model-2-npu:2",
+			call: func(m *Manager) error {
+				_, err := m.LoadModel(t.Context(), "model-2", nil)
+				return err
+			},
+			wantOp:     "LoadModel",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:     "unload_model",
+			failPath: "/openai/unload/model-2-npu:2",
+			call: func(m *Manager) error {
+				return m.UnloadModel(t.Context(), "model-2", nil, false)
+			},
+			wantOp:     "UnloadModel",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			routes := newHandler(mockCatalog(true), mockLocalModels("model-2-npu:2"))
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == tc.failPath {
+					http.Error(w, "runtime error message", tc.wantStatus)
+					return
+				}
+				routes.ServeHTTP(w, r)
+			}))
+			defer srv.Close()
+			serviceURL, err := url.Parse(srv.URL)
+			if err != nil {
+				t.Fatalf("failed to parse service URL: %v", err)
+			}
+
+			m := NewManager(WithHTTPClient(srv.Client()))
+			m.serviceURL = serviceURL
+
+			err = tc.call(m)
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("got error %v, want *APIError", err)
+			}
+			if got, want := apiErr.Op, tc.wantOp; got != want {
+				t.Errorf("got operation %q, want %q", got, want)
+			}
+			if got, want := apiErr.StatusCode, tc.wantStatus; got != want {
+				t.Errorf("got status code %d, want %d", got, want)
+			}
+			if got, want := apiErr.Body, "runtime error message"; got != want {
+				t.Errorf("got body %q, want %q", got, want)
+			}
+			u, err := url.Parse(apiErr.URL)
+			if err != nil {
+				t.Fatalf("failed to parse error URL: %v", err)
+			}
+			if got, want := u.Path, tc.failPath; got != want {
+				t.Errorf("got URL path %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+// TestSentinelErrors verifies lifecycle failures wrap the matching sentinel
+// error while preserving the underlying cause.
+func TestSentinelErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		routes    []route
+		call      func(m *Manager) error
+		err       error
+		wantCause bool
+	}{
+		{
+			name:   "catalog_failure_is_not_missing_model",
+			routes: []route{},
+			call: func(m *Manager) error {
+				_, err := m.GetModelInfo(t.Context(), "model-2", nil)
+				if errors.Is(err, ErrModelNotInCatalog) {
+					return errors.New("catalog failure reported as ErrModelNotInCatalog")
+				}
+				return err
+			},
+			wantCause: true,
+		},
+		{
+			name:   "model_not_cached",
+			routes: []route{mockCatalog(true), mockLocalModels()},
+			call: func(m *Manager) error {
+				_, err := m.LoadModel(t.Context(), "model-2", nil)
+				return err
+			},
+			err: ErrModelNotCached,
+		},
+		{
+			name:   "load_failed",
+			routes: []route{mockCatalog(true), mockLocalModels("model-2-npu:2")},
+			call: func(m *Manager) error {
+				_, err := m.LoadModel(t.Context(), "model-2", nil)
+				return err
+			},
+			err:       ErrLoadFailed,
+			wantCause: true,
+		},
+		{
+			name:   "model_not_loaded",
+			routes: []route{mockCatalog(true)},
+			call: func(m *Manager) error {
+				return m.UnloadModel(t.Context(), "model-2", nil, false)
+			},
+			err:       ErrModelNotLoaded,
+			wantCause: true,
+		},
+		{
+			name:   "download_failed",
+			routes: []route{mockCatalog(true), mockLocalModels()},
+			call: func(m *Manager) error {
+				_, err := m.DownloadModel(t.Context(), "model-2", nil)
+				return err
+			},
+			err:       ErrDownloadFailed,
+			wantCause: true,
+		},
+		{
+			name:   "upgrade_failed_keeps_download_error",
+			routes: []route{mockCatalog(true), mockLocalModels()},
+			call: func(m *Manager) error {
+				_, err := m.UpgradeModel(t.Context(), "model-2", nil, "")
+				if !errors.Is(err, ErrModelUpgradeFailed) {
+					return errors.New("upgrade failure not reported as ErrModelUpgradeFailed")
+				}
+				return err
+			},
+			err:       ErrDownloadFailed,
+			wantCause: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(newHandler(tc.routes...))
+			defer srv.Close()
+			serviceURL, err := url.Parse(srv.URL)
+			if err != nil {
+				t.Fatalf("failed to parse service URL: %v", err)
+			}
+
+			m := NewManager(WithHTTPClient(srv.Client()))
+			m.serviceURL = serviceURL
+
+			err = tc.call(m)
+			if err == nil {
+				t.Fatalf("got nil error, want non-nil error")
+			}
+			if tc.err != nil && !errors.Is(err, tc.err) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+			var apiErr *APIError
+			if got, want := errors.As(err, &apiErr), tc.wantCause; got != want {
+				t.Errorf("got underlying *APIError %t, want %t", got, want)
+			}
+		})
+	}
+}
+
+// TestServiceErrors verifies errors caused by a missing foundry executable or
+// an unreachable service are reported with their sentinel errors.
+func TestServiceErrors(t *testing.T) {
+	m := NewManager(WithServiceLauncher(&CLILauncher{Path: filepath.Join(t.TempDir(), "foundry")}))
+	if err := m.StartService(t.Context()); !errors.Is(err, ErrFoundryNotInstalled) {
+		t.Errorf("got error %v, want %v", err, ErrFoundryNotInstalled)
+	}
+
+	srv := httptest.NewServer(newHandler())
+	serviceURL, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to parse service URL: %v", err)
+	}
+	srv.Close()
+
+	m = NewManager(WithHTTPClient(srv.Client()))
+	m.serviceURL = serviceURL
+	if _, err := m.ListCachedModels(t.Context()); !errors.Is(err, ErrServiceNotRunning) {
+		t.Errorf("got error %v, want %v", err, ErrServiceNotRunning)
+	}
+}
+
+// TestValidationError verifies the message of a ValidationError.
+func TestValidationError(t *testing.T) {
+	err := &ValidationError{
+		Op:    "Chat",
+		Model: "model-2-npu:2",
+		Violations: []Violation{
+			{Field: "max_tokens", Message: "4096 exceeds the model's maximum of 1024 output tokens"},
+			{Field: "tools", Message: "model does not support tool calling"},
+		},
+	}
+	want := "Chat: invalid request for model model-2-npu:2: max_tokens: 4096 exceeds the model's maximum of 1024 output tokens; tools: model does not support tool calling"
+	if got := err.Error(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,78 @@
+package foundrylocal
+
+import (
+	"context"
+	"net/http"
+)
+
+// Operation describes the Manager operation an HTTP request to the Foundry Local
+// service belongs to.
+type Operation struct {
+	// Name is the name of the operation, usually the name of the Manager method,
+	// for example "ListCatalogModels" or "LoadModel".
+	Name string
+	// ModelID is the ID of the model the operation acts on, if any.
+	ModelID string
+}
+
+type operationKey struct{}
+
+// contextWithOperation returns a copy of ctx that carries op.
+func contextWithOperation(ctx context.Context, op Operation) context.Context {
+	return context.WithValue(ctx, operationKey{}, op)
+}
+
+// OperationFromContext returns the Operation carried by the context of a request
+// sent by the Manager. It can be used by transports set with WithTransport to
+// tell requests apart.
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	op, ok := ctx.Value(operationKey{}).(Operation)
+	return op, ok
+}
+
+// Interceptor intercepts the HTTP requests the Manager sends to the Foundry Local
+// service, for example to add correlation IDs, audit calls, or capture request and
+// response bodies. Intercept must call next to send the request, unless it returns
+// a response of its own. Each attempt of a retried request is intercepted.
+type Interceptor interface {
+	Intercept(req *http.Request, op Operation, next http.RoundTripper) (*http.Response, error)
+}
+
+// InterceptorFunc adapts a function to the Interceptor interface.
+//
+// Example:
+//
+//	audit := foundrylocal.InterceptorFunc(func(req *http.Request, op foundrylocal.Operation, next http.RoundTripper) (*http.Response, error) {
+//		req = req.Clone(req.Context())
+//		req.Header.Set("X-Correlation-ID", correlationID(req.Context()))
+//		resp, err := next.RoundTrip(req)
+//		log.Printf("%s %s: %v", op.Name, op.ModelID, err)
+//		return resp, err
+//	})
+//	manager := foundrylocal.NewManager(foundrylocal.WithInterceptors(audit))
+type InterceptorFunc func(req *http.Request, op Operation, next http.RoundTripper) (*http.Response, error)
+
+// Intercept calls f(req, op, next).
+func (f InterceptorFunc) Intercept(req *http.Request, op Operation, next http.RoundTripper) (*http.Response, error) {
+	return f(req, op, next)
+}
+
+// interceptRoundTripper passes requests to an Interceptor along with their Operation.
+type interceptRoundTripper struct {
+	interceptor Interceptor
+	next        http.RoundTripper
+}
+
+func (rt *interceptRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	op, _ := OperationFromContext(req.Context())
+	return rt.interceptor.Intercept(req, op, rt.next)
+}
+
+// intercept wraps transport with interceptors. The first interceptor is the
+// outermost one, so it sees requests first and responses last.
+func intercept(transport http.RoundTripper, interceptors []Interceptor) http.RoundTripper {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		transport = &interceptRoundTripper{interceptor: interceptors[i], next: transport}
+	}
+	return transport
+}
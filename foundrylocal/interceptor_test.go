@@ -0,0 +1,174 @@
+package foundrylocal
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingInterceptor records the operations and the order in which it saw
+// requests and responses in a log shared with other interceptors.
+type recordingInterceptor struct {
+	name string
+	mu   *sync.Mutex
+	log  *[]string
+	ops  []Operation
+}
+
+func (i *recordingInterceptor) Intercept(req *http.Request, op Operation, next http.RoundTripper) (*http.Response, error) {
+	i.mu.Lock()
+	*i.log = append(*i.log, i.name+" request")
+	i.ops = append(i.ops, op)
+	i.mu.Unlock()
+
+	resp, err := next.RoundTrip(req)
+
+	i.mu.Lock()
+	*i.log = append(*i.log, i.name+" response")
+	i.mu.Unlock()
+	return resp, err
+}
+
+// TestInterceptors verifies interceptors run in registration order around the
+// transport and receive the operation metadata of each request.
+func TestInterceptors(t *testing.T) {
+	tests := []struct {
+		name    string
+		call    func(m *Manager) error
+		wantOps []Operation
+	}{
+		{
+			name: "list_catalog_models",
+			call: func(m *Manager) error {
+				_, err := m.ListCatalogModels(t.Context())
+				return err
+			},
+			wantOps: []Operation{{Name: "ListCatalogModels"}},
+		},
+		{
+			name: "load_model",
+			call: func(m *Manager) error {
+				_, err := m.LoadModel(t.Context(), "model-2", nil)
+				return err
+			},
+			wantOps: []Operation{
+				{Name: "ListCatalogModels"},
+				{Name: "ListCachedModels"},
+				{Name: "ListLoadedModels"},
+				{Name: "LoadModel", ModelID: "model-2-npu:2"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var agents []string
+			handler := newHandler(
+				mockCatalog(true),
+				mockLocalModels("model-2-npu:2"),
+				mockJSON("/openai/load/model-2-npu:2", json.RawMessage(`{}`)))
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				agents = append(agents, r.Header.Get("User-Agent"))
+				handler.ServeHTTP(w, r)
+			}))
+			defer srv.Close()
+			serviceURL, err := url.Parse(srv.URL)
+			if err != nil {
+				t.Fatalf("failed to parse service URL: %v", err)
+			}
+
+			var (
+				mu  sync.Mutex
+				log []string
+			)
+			outer := &recordingInterceptor{name: "outer", mu: &mu, log: &log}
+			inner := &recordingInterceptor{name: "inner", mu: &mu, log: &log}
+			m := NewManager(WithHTTPClient(srv.Client()), WithInterceptors(outer), WithInterceptors(inner))
+			m.serviceURL = serviceURL
+
+			if err := tc.call(m); err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+
+			if got, want := outer.ops, tc.wantOps; !slices.Equal(got, want) {
+				t.Errorf("got operations %v, want %v", got, want)
+			}
+			if got, want := inner.ops, tc.wantOps; !slices.Equal(got, want) {
+				t.Errorf("got inner operations %v, want %v", got, want)
+			}
+			for i := 0; i < len(log); i += 4 {
+				got := log[i:min(i+4, len(log))]
+				want := []string{"outer request", "inner request", "inner response", "outer response"}
+				if !slices.Equal(got, want) {
+					t.Fatalf("got call order %v, want %v", got, want)
+				}
+			}
+			for _, agent := range agents {
+				if !strings.HasPrefix(agent, "go-foundrylocal/") {
+					t.Errorf("got User-Agent %q, want prefix %q", agent, "go-foundrylocal/")
+				}
+			}
+		})
+	}
+}
+
+// TestInterceptorRewrite verifies interceptors can modify requests and answer
+// them without calling the next transport.
+func TestInterceptorRewrite(t *testing.T) {
+	var correlationID string
+	handler := newHandler(mockCatalog(true), mockLocalModels("model-2-npu:2"))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/openai/models" {
+			correlationID = r.Header.Get("X-Correlation-ID")
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	serviceURL, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to parse service URL: %v", err)
+	}
+
+	tagging := InterceptorFunc(func(req *http.Request, op Operation, next http.RoundTripper) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.Header.Set("X-Correlation-ID", "corr-"+op.Name)
+		return next.RoundTrip(req)
+	})
+	stub := InterceptorFunc(func(req *http.Request, op Operation, next http.RoundTripper) (*http.Response, error) {
+		if op.Name != "GetCacheLocation" {
+			return next.RoundTrip(req)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"modelDirPath": "/stub"}`)),
+			Request:    req,
+		}, nil
+	})
+	m := NewManager(WithHTTPClient(srv.Client()), WithInterceptors(tagging, stub))
+	m.serviceURL = serviceURL
+
+	cached, err := m.ListCachedModels(t.Context())
+	if err != nil {
+		t.Fatalf("failed to list cached models: %v", err)
+	}
+	if got, want := len(cached), 1; got != want {
+		t.Errorf("got %d cached models, want %d", got, want)
+	}
+	if got, want := correlationID, "corr-ListCachedModels"; got != want {
+		t.Errorf("got correlation ID %q, want %q", got, want)
+	}
+
+	location, err := m.GetCacheLocation(t.Context())
+	if err != nil {
+		t.Fatalf("failed to get cache location: %v", err)
+	}
+	if got, want := location, "/stub"; got != want {
+		t.Errorf("got cache location %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,99 @@
+package foundrylocal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+)
+
+// ServiceLauncher controls the lifecycle of the Foundry Local service.
+// The Manager uses a ServiceLauncher to start, stop, and query the service
+// instead of calling the foundry command-line tool directly. The default
+// implementation is CLILauncher. Custom implementations can be installed with
+// WithServiceLauncher, for example to run lifecycle tests against a scripted
+// stand-in on machines where Foundry Local is not installed.
+type ServiceLauncher interface {
+	// Start starts the Foundry Local service. Implementations must succeed
+	// if the service is already running.
+	Start(ctx context.Context) error
+
+	// Stop stops the Foundry Local service.
+	Stop(ctx context.Context) error
+
+	// Status reports the state of the Foundry Local service in the format
+	// printed by 'foundry service status'. A running service is reported
+	// with a line containing "is running on <endpoint URL>".
+	Status(ctx context.Context) (string, error)
+}
+
+// CLILauncher is the default ServiceLauncher. It invokes the Foundry Local
+// command-line tool. The zero value runs "foundry" from PATH using the
+// environment and working directory of the current process.
+//
+// Example:
+//
+//	launcher := &foundrylocal.CLILauncher{
+//		Path: "/opt/foundry/bin/foundry",
+//		Env:  append(os.Environ(), "FOUNDRY_LOG_LEVEL=debug"),
+//	}
+//	manager := foundrylocal.NewManager(foundrylocal.WithServiceLauncher(launcher))
+type CLILauncher struct {
+	// Path is the name or path of the foundry executable.
+	// If empty, "foundry" is resolved using PATH.
+	Path string
+
+	// Env specifies the environment of the foundry process.
+	// If nil, the environment of the current process is used.
+	Env []string
+
+	// Dir specifies the working directory of the foundry process.
+	// If empty, the current working directory is used.
+	Dir string
+}
+
+// Start runs 'foundry service start'.
+func (l *CLILauncher) Start(ctx context.Context) error {
+	_, err := l.Run(ctx, "service", "start")
+	return err
+}
+
+// Stop runs 'foundry service stop'.
+func (l *CLILauncher) Stop(ctx context.Context) error {
+	_, err := l.Run(ctx, "service", "stop")
+	return err
+}
+
+// Status runs 'foundry service status' and returns its output.
+func (l *CLILauncher) Status(ctx context.Context) (string, error) {
+	return l.Run(ctx, "service", "status")
+}
+
+// Run executes the foundry command-line tool with the given arguments
+// and returns the combined stdout and stderr output. If the executable
+// cannot be found, the error wraps ErrFoundryNotInstalled.
+func (l *CLILauncher) Run(ctx context.Context, args ...string) (string, error) {
+	path := l.Path
+	if path == "" {
+		path = "foundry"
+	}
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Env = l.Env
+	cmd.Dir = l.Dir
+	bytes, err := cmd.CombinedOutput()
+	if isNotInstalled(err, cmd.Path) {
+		err = fmt.Errorf("%w: %w", ErrFoundryNotInstalled, err)
+	}
+	return string(bytes), err
+}
+
+// isNotInstalled reports whether err indicates that the executable at path does
+// not exist. A missing working directory is not reported as a missing executable.
+func isNotInstalled(err error, path string) bool {
+	if errors.Is(err, exec.ErrNotFound) {
+		return true
+	}
+	var pathErr *fs.PathError
+	return errors.As(err, &pathErr) && pathErr.Path == path && errors.Is(pathErr, fs.ErrNotExist)
+}
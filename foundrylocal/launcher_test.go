@@ -0,0 +1,288 @@
+package foundrylocal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// fakeLauncher is a scripted ServiceLauncher used to exercise the Manager's
+// service lifecycle without the foundry command-line tool.
+// If startStatus is set, a successful Start replaces the status report with it,
+// and Stop resets the status report to "not running". If statusErr is set, Status
+// fails with it until Start succeeds.
+type fakeLauncher struct {
+	mu          sync.Mutex
+	status      string
+	startStatus string
+	startErr    error
+	statusErr   error
+	starts      int
+	stops       int
+}
+
+func (l *fakeLauncher) Start(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.starts++
+	if l.startErr == nil && l.startStatus != "" {
+		l.status = l.startStatus
+		l.statusErr = nil
+	}
+	return l.startErr
+}
+
+func (l *fakeLauncher) Stop(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stops++
+	if l.startStatus != "" {
+		l.status = "Model management service is not running!\n"
+	}
+	return nil
+}
+
+func (l *fakeLauncher) Status(ctx context.Context) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.status, l.statusErr
+}
+
+// setStatus replaces the status report returned by Status.
+func (l *fakeLauncher) setStatus(status string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.status = status
+}
+
+// TestServiceLauncher verifies StartService and StopService delegate to the
+// configured ServiceLauncher and resolve the endpoint from its status report.
+func TestServiceLauncher(t *testing.T) {
+	tests := []struct {
+		name         string
+		launcher     *fakeLauncher
+		wantEndpoint string
+		wantStarts   int
+		wantErr      bool
+	}{
+		{
+			name: "start_resolves_endpoint",
+			launcher: &fakeLauncher{
+				status:      "🔴 Model management service is not running!\n",
+				startStatus: "🟢 Model management service is running on http://127.0.0.1:5273/openai/status\n",
+			},
+			wantEndpoint: "http://127.0.0.1:5273/v1",
+			wantStarts:   1,
+		},
+		{
+			name: "status_fails_when_stopped",
+			launcher: &fakeLauncher{
+				statusErr:   errors.New("exit status 1"),
+				startStatus: "🟢 Model management service is running on http://127.0.0.1:5273/openai/status\n",
+			},
+			wantEndpoint: "http://127.0.0.1:5273/v1",
+			wantStarts:   1,
+		},
+		{
+			name:       "start_fails",
+			launcher:   &fakeLauncher{startErr: errors.New("boom")},
+			wantStarts: 1,
+			wantErr:    true,
+		},
+		{
+			name:     "not_installed",
+			launcher: &fakeLauncher{statusErr: fmt.Errorf("%w: exec: \"foundry\": executable file not found in $PATH", ErrFoundryNotInstalled)},
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewManager(WithServiceLauncher(tc.launcher))
+
+			err := m.StartService(t.Context())
+			if got, want := err != nil, tc.wantErr; got != want {
+				t.Fatalf("got error %v, want error %t", err, want)
+			}
+			if got, want := tc.launcher.starts, tc.wantStarts; got != want {
+				t.Errorf("got %d starts, want %d", got, want)
+			}
+			if tc.wantErr {
+				return
+			}
+
+			if got, want := m.Endpoint().String(), tc.wantEndpoint; got != want {
+				t.Errorf("got endpoint %q, want %q", got, want)
+			}
+			if err := m.StopService(t.Context()); err != nil {
+				t.Fatalf("failed to stop service: %v", err)
+			}
+			if got, want := tc.launcher.stops, 1; got != want {
+				t.Errorf("got %d stops, want %d", got, want)
+			}
+			if got, want := m.IsServiceRunning(), false; got != want {
+				t.Errorf("got service running %t, want %t", got, want)
+			}
+		})
+	}
+}
+
+// TestOwnership verifies StopService leaves services it did not start running
+// unless the Manager was configured with OwnershipExclusive.
+func TestOwnership(t *testing.T) {
+	const running = "Model management service is running on http://127.0.0.1:5273/openai/status\n"
+	const notRunning = "Model management service is not running!\n"
+
+	tests := []struct {
+		name       string
+		status     string
+		ownership  Ownership
+		wantStarts int
+		wantStops  int
+	}{
+		{
+			name:       "shared_foreign_service",
+			status:     running,
+			ownership:  OwnershipShared,
+			wantStarts: 0,
+			wantStops:  0,
+		},
+		{
+			name:       "shared_own_service",
+			status:     notRunning,
+			ownership:  OwnershipShared,
+			wantStarts: 1,
+			wantStops:  1,
+		},
+		{
+			name:       "exclusive_foreign_service",
+			status:     running,
+			ownership:  OwnershipExclusive,
+			wantStarts: 0,
+			wantStops:  1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			launcher := &fakeLauncher{status: tc.status, startStatus: running}
+			m := NewManager(WithServiceLauncher(launcher), WithOwnership(tc.ownership))
+
+			if err := m.StartService(t.Context()); err != nil {
+				t.Fatalf("failed to start service: %v", err)
+			}
+			if err := m.StopService(t.Context()); err != nil {
+				t.Fatalf("failed to stop service: %v", err)
+			}
+			if got, want := launcher.starts, tc.wantStarts; got != want {
+				t.Errorf("got %d starts, want %d", got, want)
+			}
+			if got, want := launcher.stops, tc.wantStops; got != want {
+				t.Errorf("got %d stops, want %d", got, want)
+			}
+			if got, want := m.IsServiceRunning(), false; got != want {
+				t.Errorf("got service running %t, want %t", got, want)
+			}
+		})
+	}
+}
+
+// TestCLILauncher verifies CLILauncher runs the configured executable with the
+// expected arguments, environment, and working directory.
+func TestCLILauncher(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+
+	// The script only uses shell builtins, since Env does not include PATH.
+	script := filepath.Join(t.TempDir(), "foundry")
+	const content = `#!/bin/sh
+echo "$*" >> calls
+if [ "$1" = "fail" ]; then
+	echo "failed" >&2
+	exit 1
+fi
+echo "args=$* env=$FOUNDRY_TEST dir=$(pwd)"
+`
+	if err := os.WriteFile(script, []byte(content), 0o755); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+	dir := t.TempDir()
+	launcher := &CLILauncher{Path: script, Env: []string{"FOUNDRY_TEST=value"}, Dir: dir}
+
+	if err := launcher.Start(t.Context()); err != nil {
+		t.Fatalf("failed to start: %v", err)
+	}
+	if err := launcher.Stop(t.Context()); err != nil {
+		t.Fatalf("failed to stop: %v", err)
+	}
+	status, err := launcher.Status(t.Context())
+	if err != nil {
+		t.Fatalf("failed to get status: %v", err)
+	}
+	if got, want := status, "args=service status env=value dir="+dir+"\n"; got != want {
+		t.Errorf("got status %q, want %q", got, want)
+	}
+	out, err := launcher.Run(t.Context(), "fail")
+	if err == nil || errors.Is(err, ErrFoundryNotInstalled) {
+		t.Errorf("got error %v, want exit error", err)
+	}
+	if got, want := out, "failed\n"; got != want {
+		t.Errorf("got output %q, want %q", got, want)
+	}
+
+	calls, err := os.ReadFile(filepath.Join(dir, "calls"))
+	if err != nil {
+		t.Fatalf("failed to read calls: %v", err)
+	}
+	if got, want := string(calls), "service start\nservice stop\nservice status\nfail\n"; got != want {
+		t.Errorf("got calls %q, want %q", got, want)
+	}
+}
+
+// TestCLILauncherErrors verifies CLILauncher reports ErrFoundryNotInstalled only
+// if the foundry executable is missing.
+func TestCLILauncherErrors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+	dir := t.TempDir()
+
+	tests := []struct {
+		name             string
+		launcher         *CLILauncher
+		wantNotInstalled bool
+	}{
+		{
+			name:             "not_in_path",
+			launcher:         &CLILauncher{Path: "foundry-does-not-exist"},
+			wantNotInstalled: true,
+		},
+		{
+			name:             "missing_executable",
+			launcher:         &CLILauncher{Path: filepath.Join(dir, "foundry")},
+			wantNotInstalled: true,
+		},
+		{
+			name:     "missing_dir",
+			launcher: &CLILauncher{Path: "/bin/sh", Dir: filepath.Join(dir, "missing")},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.launcher.Run(t.Context(), "-c", "exit 0")
+			if err == nil {
+				t.Fatal("got no error, want error")
+			}
+			if got, want := errors.Is(err, ErrFoundryNotInstalled), tc.wantNotInstalled; got != want {
+				t.Errorf("got error %v, want ErrFoundryNotInstalled %t", err, want)
+			}
+		})
+	}
+}
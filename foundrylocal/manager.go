@@ -39,7 +39,6 @@ package foundrylocal
 
 import (
 	"bufio"
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -48,7 +47,6 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
-	"os/exec"
 	"regexp"
 	"slices"
 	"strconv"
@@ -85,6 +83,57 @@ var (
 
 	// ErrReadLoadedModels is returned when the list of loaded models cannot be read.
 	ErrReadLoadedModels = errors.New("failed to read loaded models")
+
+	// ErrServiceNotRunning is returned when the Foundry Local service is not running or not reachable.
+	ErrServiceNotRunning = errors.New("foundry service is not running")
+
+	// ErrFoundryNotInstalled is returned when the foundry command-line tool cannot be found.
+	ErrFoundryNotInstalled = errors.New("foundry command-line tool is not installed")
+
+	// ErrModelNotCached is returned when a model must be downloaded before it can be used.
+	ErrModelNotCached = errors.New("model not found in local cache")
+
+	// ErrModelNotLoaded is returned when a model is expected to be loaded but is not.
+	ErrModelNotLoaded = errors.New("model is not loaded")
+
+	// ErrDownloadFailed is returned when a model download fails.
+	ErrDownloadFailed = errors.New("failed to download model")
+
+	// ErrLoadFailed is returned when the service fails to load a model.
+	ErrLoadFailed = errors.New("failed to load model")
+
+	// ErrUnsupportedRuntime is returned when the Foundry Local runtime is older than MinRuntimeVersion.
+	ErrUnsupportedRuntime = errors.New("unsupported foundry local runtime version")
+
+	// ErrModelIncompatible is returned when a model requires a newer Foundry Local runtime
+	// than the one that is running.
+	ErrModelIncompatible = errors.New("model requires a newer foundry local runtime")
+
+	// ErrCommandsNotSupported is returned when an operation requires running foundry commands,
+	// but the Manager's ServiceLauncher does not implement CommandRunner.
+	ErrCommandsNotSupported = errors.New("service launcher cannot run foundry commands")
+
+	// ErrInvalidServiceConfig is returned when a ServiceConfig fails validation.
+	ErrInvalidServiceConfig = errors.New("invalid service configuration")
+
+	// ErrToolCallingNotSupported is returned when tools are used with a model that
+	// does not support tool calling. Chat and ChatStream report it in a ValidationError.
+	ErrToolCallingNotSupported = errors.New("model does not support tool calling")
+
+	// ErrToolRoundsExceeded is returned by RunTools when the model keeps requesting
+	// tool calls after the maximum number of rounds.
+	ErrToolRoundsExceeded = errors.New("too many tool call rounds")
+
+	// ErrSchemaViolation is returned when a JSON value does not match a JSONSchema.
+	ErrSchemaViolation = errors.New("value does not match schema")
+
+	// ErrInvalidJSONResponse is returned by ChatJSON when the model does not return
+	// valid JSON after all attempts.
+	ErrInvalidJSONResponse = errors.New("model did not return valid JSON")
+
+	// ErrContextWindowExceeded is returned by Session.Send when a message does not fit
+	// into the context window of the model.
+	ErrContextWindowExceeded = errors.New("message does not fit into the context window")
 )
 
 type sdkRoundTripper struct {
@@ -106,6 +155,7 @@ func (rt *sdkRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
 //   - Service URL for the running Foundry Local instance
 //   - Cached model catalog and mapping
 //   - OS specific configuration
+//   - ServiceLauncher used to control the Foundry Local service
 //
 // Example:
 //
@@ -120,6 +170,19 @@ type Manager struct {
 	serviceURL         *url.URL
 	catalogModels      []ModelInfo
 	useWindowsFallback bool
+	launcher           ServiceLauncher
+	endpoint           *url.URL
+	runtimeVersion     string
+	autoRecover        bool
+	ownership          Ownership
+	startedService     bool
+	loadedModels       []string
+	retryPolicy        RetryPolicy
+	baseClient         *http.Client
+	transport          http.RoundTripper
+	timeouts           Timeouts
+	interceptors       []Interceptor
+	validationMode     ValidationMode
 
 	// ApiKey is the API key used for authentication with external services.
 	// Default value is "OPENAI_API_KEY".
@@ -146,6 +209,8 @@ func NewManager(opts ...ManagerOption) *Manager {
 	m := &Manager{
 		ApiKey:             "OPENAI_API_KEY",
 		useWindowsFallback: false,
+		launcher:           &CLILauncher{},
+		timeouts:           DefaultTimeouts(),
 	}
 
 	// Make sure we always apply OS-specific defaults
@@ -157,6 +222,7 @@ func NewManager(opts ...ManagerOption) *Manager {
 	if m.Logger == nil {
 		m.Logger = slog.New(slog.DiscardHandler)
 	}
+	m.client = m.newClient()
 	return m
 }
 
@@ -235,8 +301,45 @@ func StartModel(ctx context.Context, aliasorModelID string, device *DeviceType)
 	return m, nil
 }
 
+// Connect creates a new Manager that is attached to an already running Foundry Local
+// service at the given endpoint, such as "http://127.0.0.1:5273". The foundry
+// command-line tool is not used, which makes Connect suitable for containers and
+// sidecars where the CLI is not installed. Connect returns an error if the service
+// does not respond to a readiness probe.
+//
+// Since the runtime version cannot be detected without the CLI, Connect does not
+// check it against MinRuntimeVersion, and models are not checked for compatibility
+// with the runtime.
+//
+// Example:
+//
+//	manager, err := foundrylocal.Connect(ctx, "http://127.0.0.1:5273")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func Connect(ctx context.Context, endpoint string, opts ...ManagerOption) (*Manager, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+	m := NewManager(slices.Concat(opts, []ManagerOption{WithEndpoint(u)})...)
+	if err := m.StartService(ctx); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // StartService starts the Foundry Local service if it's not already running.
 // This method is idempotent - calling it multiple times is safe.
+// If the service is already running, for example because another application
+// started it, StartService attaches to it and records that this Manager does
+// not own the service (see WithOwnership).
+// If the Manager was configured with WithEndpoint, StartService does not start
+// the service but probes the configured endpoint for readiness instead.
+//
+// StartService detects the runtime version and returns ErrUnsupportedRuntime
+// if the runtime is older than MinRuntimeVersion. The runtime version is not
+// detected or checked with WithEndpoint.
 //
 // Example:
 //
@@ -250,24 +353,61 @@ func (m *Manager) StartService(ctx context.Context) error {
 		return nil
 	}
 
-	endpoint, err := m.ensureServiceRunning(ctx)
+	if m.endpoint != nil {
+		probeCtx, cancel := m.operationContext(ctx, classMetadata)
+		err := probeStatus(probeCtx, m.client, m.endpoint)
+		cancel()
+		if err != nil {
+			m.Logger.ErrorContext(ctx, "Foundry service is not ready", "endpoint", m.endpoint.String(), "error", err)
+			return err
+		}
+		m.serviceURL = m.endpoint
+		m.Logger.InfoContext(ctx, "Attached to Foundry service", "endpoint", m.serviceURL.String())
+		return nil
+	}
+
+	endpoint, started, err := m.ensureServiceRunning(ctx)
 	if err != nil {
 		m.Logger.ErrorContext(ctx, "Foundry service did not start", "error", err)
 		return err
 	}
 
-	m.serviceURL = endpoint
-	m.client = &http.Client{
-		Timeout:   time.Duration(2) * time.Hour,
-		Transport: &sdkRoundTripper{http.DefaultTransport},
+	runtimeVersion, err := m.detectRuntimeVersion(ctx)
+	if err != nil {
+		m.Logger.WarnContext(ctx, "failed to detect Foundry runtime version", "error", err)
+	}
+	if err := checkRuntimeVersion(runtimeVersion); err != nil {
+		m.Logger.ErrorContext(ctx, "Foundry runtime is not supported", "version", runtimeVersion, "error", err)
+		if started {
+			// Do not leave a service behind that this Manager started but cannot use.
+			if stopErr := m.launcher.Stop(ctx); stopErr != nil {
+				m.Logger.ErrorContext(ctx, "failed to stop unsupported Foundry service", "error", stopErr)
+				return errors.Join(err, stopErr)
+			}
+		}
+		return err
 	}
-	m.Logger.InfoContext(ctx, "Foundry service started successfully", "endpoint", m.serviceURL.String())
+
+	m.serviceURL = endpoint
+	m.startedService = started
+	m.runtimeVersion = runtimeVersion
+	m.Logger.InfoContext(ctx, "Foundry service started successfully", "endpoint", m.serviceURL.String(),
+		"version", m.runtimeVersion, "startedByManager", started)
 	return nil
 }
 
 // StopService stops the Foundry Local service if it's currently running.
 // This method is idempotent - calling it multiple times is safe.
 // After stopping, the Manager will need to be restarted before performing model operations.
+// If the Manager was configured with WithEndpoint, StopService only detaches from the
+// service and leaves it running.
+//
+// With the default OwnershipShared, StopService only stops a service that this
+// Manager started and leaves services started by other applications running.
+// Use WithOwnership(OwnershipExclusive) to always stop the service.
+//
+// StopService forgets the models loaded with LoadModel, so a later Shutdown does
+// not unload them.
 //
 // Example:
 //
@@ -282,9 +422,27 @@ func (m *Manager) StopService(ctx context.Context) error {
 		return nil
 	}
 
-	_, err := m.invokeFoundry(ctx, "service stop")
+	// Models loaded by this Manager are no longer tracked once it stops or detaches
+	// from the service, so Shutdown does not restart the service to unload them.
+	m.loadedModels = nil
+
+	if m.endpoint != nil {
+		m.serviceURL = nil
+		m.Logger.InfoContext(ctx, "Detached from Foundry service", "endpoint", m.endpoint.String())
+		return nil
+	}
+
+	if m.ownership == OwnershipShared && !m.startedService {
+		m.serviceURL = nil
+		m.runtimeVersion = ""
+		m.Logger.InfoContext(ctx, "Foundry service was not started by this Manager, leaving it running")
+		return nil
+	}
+
+	err := m.launcher.Stop(ctx)
 	m.serviceURL = nil
-	m.client = nil
+	m.runtimeVersion = ""
+	m.startedService = false
 	m.Logger.InfoContext(ctx, "Foundry service stopped")
 	return err
 }
@@ -311,22 +469,17 @@ func (m *Manager) ListCatalogModels(ctx context.Context) ([]ModelInfo, error) {
 		return nil, err
 	}
 
-	endpoint := m.serviceURL.JoinPath("foundry", "list")
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := m.client.Do(req)
+	resp, err := m.do(ctx, operation{
+		name:       "ListCatalogModels",
+		method:     http.MethodGet,
+		path:       []string{"foundry", "list"},
+		idempotent: true,
+	})
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if !ensureSuccessStatusCode(resp) {
-		return nil, fmt.Errorf("received non-success status code %d", resp.StatusCode)
-	}
-
 	var models []ModelInfo
 	if err = json.NewDecoder(resp.Body).Decode(&models); err != nil || models == nil {
 		return []ModelInfo{}, err
@@ -369,7 +522,8 @@ func (m *Manager) RefreshCatalog() {
 // GetModelInfo retrieves detailed information about a specific model by its ID or alias.
 // The optional device parameter narrows alias matches to a preferred device type;
 // pass nil to allow any device. The method returns the model metadata or
-// ErrModelNotInCatalog if no match is found.
+// ErrModelNotInCatalog if no match is found. Errors reading the catalog, for example
+// ErrServiceNotRunning, are returned as is.
 //
 // The function uses a priority system when multiple models share the same alias,
 // preferring models with higher-priority execution providers based on the Manager's
@@ -388,7 +542,7 @@ func (m *Manager) RefreshCatalog() {
 func (m *Manager) GetModelInfo(ctx context.Context, aliasOrModelID string, device *DeviceType) (ModelInfo, error) {
 	catalog, err := m.ListCatalogModels(ctx)
 	if err != nil {
-		return ModelInfo{}, ErrModelNotInCatalog
+		return ModelInfo{}, err
 	}
 
 	// 1) Try to match by full ID exactly (with or without ':' for backwards compatibility)
@@ -464,13 +618,12 @@ func (m *Manager) GetCacheLocation(ctx context.Context) (string, error) {
 		return "", err
 	}
 
-	endpoint := m.serviceURL.JoinPath("openai", "status")
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
-	if err != nil {
-		return "", err
-	}
-
-	resp, err := m.client.Do(req)
+	resp, err := m.do(ctx, operation{
+		name:       "GetCacheLocation",
+		method:     http.MethodGet,
+		path:       []string{"openai", "status"},
+		idempotent: true,
+	})
 	if err != nil {
 		return "", err
 	}
@@ -507,22 +660,17 @@ func (m *Manager) ListCachedModels(ctx context.Context) ([]ModelInfo, error) {
 		return nil, err
 	}
 
-	endpoint := m.serviceURL.JoinPath("openai", "models")
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := m.client.Do(req)
+	resp, err := m.do(ctx, operation{
+		name:       "ListCachedModels",
+		method:     http.MethodGet,
+		path:       []string{"openai", "models"},
+		idempotent: true,
+	})
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("received non-success status code %d", resp.StatusCode)
-	}
-
 	var models []string
 	if err = json.NewDecoder(resp.Body).Decode(&models); err != nil || models == nil {
 		return []ModelInfo{}, err
@@ -564,6 +712,9 @@ func (m *Manager) DownloadModel(ctx context.Context, aliasorModelID string, devi
 	if err != nil {
 		return ModelInfo{}, err
 	}
+	if err := m.checkModelCompatibility(modelInfo); err != nil {
+		return ModelInfo{}, err
+	}
 
 	localModels, err := m.ListCachedModels(ctx)
 	if err != nil {
@@ -590,30 +741,26 @@ func (m *Manager) DownloadModel(ctx context.Context, aliasorModelID string, devi
 		return ModelInfo{}, err
 	}
 
-	endpoint := m.serviceURL.JoinPath("openai", "download")
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewBuffer(requestBody))
-	if err != nil {
-		return ModelInfo{}, err
-	}
-
 	m.Logger.InfoContext(ctx, "downloading model", "alias", modelInfo.Alias, "modelID", modelInfo.ID)
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := m.client.Do(req)
+	resp, err := m.do(ctx, operation{
+		name:    "DownloadModel",
+		method:  http.MethodPost,
+		path:    []string{"openai", "download"},
+		body:    requestBody,
+		class:   classDownload,
+		modelID: modelInfo.ID,
+	})
 	if err != nil {
-		return ModelInfo{}, err
+		return ModelInfo{}, fmt.Errorf("%w: %w", ErrDownloadFailed, err)
 	}
 	defer resp.Body.Close()
 
-	if !ensureSuccessStatusCode(resp) {
-		return ModelInfo{}, fmt.Errorf("received non-success status code %d", resp.StatusCode)
-	}
-
 	responseBodyBytes, _ := io.ReadAll(resp.Body)
 	responseBody := string(responseBodyBytes)
 	// Find the last '{' to get the start of the JSON object
 	jsonStart := strings.LastIndex(responseBody, "{")
 	if jsonStart == -1 {
-		return ModelInfo{}, fmt.Errorf("no JSON object found in response")
+		return ModelInfo{}, fmt.Errorf("%w: no JSON object found in response", ErrDownloadFailed)
 	}
 	jsonPart := responseBody[jsonStart:]
 	var jsonDoc struct {
@@ -622,11 +769,11 @@ func (m *Manager) DownloadModel(ctx context.Context, aliasorModelID string, devi
 	}
 
 	if err := json.Unmarshal([]byte(jsonPart), &jsonDoc); err != nil {
-		return ModelInfo{}, err
+		return ModelInfo{}, fmt.Errorf("%w: %w", ErrDownloadFailed, err)
 	}
 
 	if !jsonDoc.Success {
-		return ModelInfo{}, fmt.Errorf("failed to download model: %s", jsonDoc.ErrorMessage)
+		return ModelInfo{}, fmt.Errorf("%w: %s", ErrDownloadFailed, jsonDoc.ErrorMessage)
 	}
 	return modelInfo, nil
 }
@@ -670,6 +817,9 @@ func (m *Manager) LoadModel(ctx context.Context, aliasOrModelID string, device *
 	if err != nil {
 		return ModelInfo{}, err
 	}
+	if err := m.checkModelCompatibility(modelInfo); err != nil {
+		return ModelInfo{}, err
+	}
 
 	localModelInfo, err := m.ListCachedModels(ctx)
 	if err != nil {
@@ -677,11 +827,9 @@ func (m *Manager) LoadModel(ctx context.Context, aliasOrModelID string, device *
 	}
 
 	if !slices.ContainsFunc(localModelInfo, matchAliasOrId(aliasOrModelID)) {
-		return ModelInfo{}, fmt.Errorf("model %s not found in local models, download first", aliasOrModelID)
+		return ModelInfo{}, fmt.Errorf("%w: model %s not found in local models, download first", ErrModelNotCached, aliasOrModelID)
 	}
 
-	endpoint := *m.serviceURL.JoinPath("openai", "load", modelInfo.ID)
-
 	params := url.Values{}
 	// Note: The C# SDK still sets this value as "timeout", but the REST API specifies it as "ttl".
 	// See https://learn.microsoft.com/en-us/azure/ai-foundry/foundry-local/reference/reference-rest#get-openailoadname
@@ -691,16 +839,36 @@ func (m *Manager) LoadModel(ctx context.Context, aliasOrModelID string, device *
 		params.Set("ep", modelInfo.EPOverride)
 	}
 
-	endpoint.RawQuery = params.Encode()
+	// Models that are already loaded may belong to other applications, so they are
+	// not tracked for Shutdown. If the loaded models cannot be listed, the model is
+	// not tracked either.
+	track := false
+	loadedModels, err := m.ListLoadedModels(ctx)
+	if err != nil {
+		m.Logger.WarnContext(ctx, "failed to list loaded models, model will not be unloaded on shutdown", "modelID", modelInfo.ID, "error", err)
+	} else {
+		track = !slices.ContainsFunc(loadedModels, func(loaded ModelInfo) bool {
+			return strings.EqualFold(loaded.ID, modelInfo.ID)
+		})
+	}
+
 	m.Logger.InfoContext(ctx, "loading model", "alias", modelInfo.Alias, "modelID", modelInfo.ID)
-	resp, err := m.client.Get(endpoint.String())
+	resp, err := m.do(ctx, operation{
+		name:       "LoadModel",
+		method:     http.MethodGet,
+		path:       []string{"openai", "load", modelInfo.ID},
+		query:      params,
+		idempotent: true,
+		class:      classLoad,
+		modelID:    modelInfo.ID,
+	})
 	if err != nil {
-		return ModelInfo{}, err
+		return ModelInfo{}, fmt.Errorf("%w: %w", ErrLoadFailed, err)
 	}
 	defer resp.Body.Close()
 
-	if !ensureSuccessStatusCode(resp) {
-		return ModelInfo{}, fmt.Errorf("received non-success status code %d", resp.StatusCode)
+	if track {
+		m.trackLoadedModel(modelInfo.ID)
 	}
 	return modelInfo, nil
 }
@@ -741,10 +909,10 @@ func (m *Manager) DownloadModelWithProgress(ctx context.Context, aliasOrModelID
 	}
 	progressChan := make(chan ModelDownloadProgress, 1)
 
-	if m.client == nil {
+	if m.serviceURL == nil {
 		go func() {
 			defer close(progressChan)
-			progressChan <- NewDownloadError("service not started")
+			progressChan <- NewDownloadError(ErrServiceNotRunning.Error())
 		}()
 		return progressChan, nil
 	}
@@ -757,6 +925,10 @@ func (m *Manager) DownloadModelWithProgress(ctx context.Context, aliasOrModelID
 			progressChan <- NewDownloadError(err.Error())
 			return
 		}
+		if err := m.checkModelCompatibility(modelInfo); err != nil {
+			progressChan <- NewDownloadError(err.Error())
+			return
+		}
 
 		localModels, err := m.ListCachedModels(ctx)
 		if err != nil {
@@ -787,24 +959,19 @@ func (m *Manager) DownloadModelWithProgress(ctx context.Context, aliasOrModelID
 			return
 		}
 
-		endpoint := m.serviceURL.JoinPath("openai", "download")
-		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewBuffer(bodyBytes))
-		if err != nil {
-			progressChan <- NewDownloadError(err.Error())
-			return
-		}
-
-		req.Header.Set("Content-Type", "application/json")
-		resp, err := m.client.Do(req)
+		resp, err := m.do(ctx, operation{
+			name:    "DownloadModelWithProgress",
+			method:  http.MethodPost,
+			path:    []string{"openai", "download"},
+			body:    bodyBytes,
+			class:   classDownload,
+			modelID: modelInfo.ID,
+		})
 		if err != nil {
 			progressChan <- NewDownloadError(err.Error())
 			return
 		}
 		defer resp.Body.Close()
-		if !ensureSuccessStatusCode(resp) {
-			progressChan <- NewDownloadError(fmt.Sprintf("received non-success status code %d", resp.StatusCode))
-			return
-		}
 
 		scanner := bufio.NewScanner(resp.Body)
 		var jsonBuilder strings.Builder
@@ -894,16 +1061,20 @@ func (m *Manager) DownloadModelWithProgress(ctx context.Context, aliasOrModelID
 //		}
 //	}
 func (m *Manager) ListLoadedModels(ctx context.Context) ([]ModelInfo, error) {
-	endpoint := m.serviceURL.JoinPath("openai", "loadedmodels")
-	resp, err := m.client.Get(endpoint.String())
-	if err != nil {
+	if err := m.StartService(ctx); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if !ensureSuccessStatusCode(resp) {
-		return nil, fmt.Errorf("received non-success status code %d", resp.StatusCode)
+	resp, err := m.do(ctx, operation{
+		name:       "ListLoadedModels",
+		method:     http.MethodGet,
+		path:       []string{"openai", "loadedmodels"},
+		idempotent: true,
+	})
+	if err != nil {
+		return nil, err
 	}
+	defer resp.Body.Close()
 
 	// Use a pointer to a slice to decode the JSON response
 	// This allows us to unmarshal a "null" response as nil.
@@ -927,25 +1098,37 @@ func (m *Manager) ListLoadedModels(ctx context.Context) ([]ModelInfo, error) {
 //		fmt.Println("Model unloaded successfully")
 //	}
 func (m *Manager) UnloadModel(ctx context.Context, aliasOrModelID string, device *DeviceType, force bool) error {
+	if err := m.StartService(ctx); err != nil {
+		return err
+	}
+
 	modelInfo, err := m.GetModelInfo(ctx, aliasOrModelID, device)
 	if err != nil {
 		return err
 	}
 
-	endpoint := m.serviceURL.JoinPath("openai", "unload", modelInfo.ID)
 	params := url.Values{}
 	params.Set("force", strconv.FormatBool(force))
-	endpoint.RawQuery = params.Encode()
 	m.Logger.InfoContext(ctx, "unloading model", "alias", modelInfo.Alias, "modelID", modelInfo.ID)
-	resp, err := m.client.Get(endpoint.String())
+	resp, err := m.do(ctx, operation{
+		name:       "UnloadModel",
+		method:     http.MethodGet,
+		path:       []string{"openai", "unload", modelInfo.ID},
+		query:      params,
+		idempotent: true,
+		class:      classLoad,
+		modelID:    modelInfo.ID,
+	})
 	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("%w: %w", ErrModelNotLoaded, err)
+		}
 		return err
 	}
 	defer resp.Body.Close()
 
-	if !ensureSuccessStatusCode(resp) {
-		return fmt.Errorf("received non-success status code %d", resp.StatusCode)
-	}
+	m.untrackLoadedModel(modelInfo.ID)
 	return nil
 }
 
@@ -1054,7 +1237,7 @@ func (m *Manager) UpgradeModel(ctx context.Context, aliasOrModelID string, devic
 	}
 	mi, err := m.DownloadModel(ctx, modelInfo.ID, device, opts...)
 	if err != nil {
-		return ModelInfo{}, ErrModelUpgradeFailed
+		return ModelInfo{}, fmt.Errorf("%w: %w", ErrModelUpgradeFailed, err)
 	}
 	return mi, nil
 
@@ -1080,28 +1263,52 @@ func (m *Manager) fetchModelInfo(ctx context.Context, aliasesOrModelIDs ...strin
 	return modelInfos, nil
 }
 
+// probeStatus checks that the Foundry Local service at endpoint is ready by
+// requesting its status resource.
+func probeStatus(ctx context.Context, client *http.Client, endpoint *url.URL) error {
+	var status json.RawMessage
+	return getJSON(ctx, client, "StartService", endpoint.JoinPath("openai", "status"), &status)
+}
+
 // ensureServiceRunning starts the Foundry Local service if it's not already running
-// and returns the service endpoint URL.
-func (m *Manager) ensureServiceRunning(ctx context.Context) (*url.URL, error) {
-	cmd := exec.CommandContext(ctx, "foundry", "service", "start")
-	err := cmd.Run()
+// and returns the service endpoint URL. started reports whether the service was
+// started by this call.
+func (m *Manager) ensureServiceRunning(ctx context.Context) (endpoint *url.URL, started bool, err error) {
+	endpoint, err = m.statusEndpoint(ctx)
+	if err == nil {
+		return endpoint, false, nil
+	}
+	if !errors.Is(err, ErrServiceNotRunning) {
+		return nil, false, err
+	}
+
+	if err := m.launcher.Start(ctx); err != nil {
+		return nil, false, err
+	}
+	endpoint, err = m.statusEndpoint(ctx)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
-	return m.statusEndpoint(ctx)
+	return endpoint, true, nil
 }
 
-// statusEndpoint retrieves the current service endpoint URL by calling 'foundry service status'
-// and parsing the output for the running service URL.
+// statusEndpoint retrieves the current service endpoint URL from the ServiceLauncher's
+// status report and parses the output for the running service URL. It returns
+// ErrServiceNotRunning if the report does not contain a running service URL or
+// the status report fails for any reason other than ErrFoundryNotInstalled, since
+// some runtime versions report a stopped service with a non-zero exit code.
 func (m *Manager) statusEndpoint(ctx context.Context) (*url.URL, error) {
-	statusResult, err := m.invokeFoundry(ctx, "service status")
+	statusResult, err := m.launcher.Status(ctx)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, ErrFoundryNotInstalled) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %w", ErrServiceNotRunning, err)
 	}
 
 	matches := testIsRunning.FindStringSubmatch(statusResult)
 	if len(matches) < 2 {
-		return nil, nil
+		return nil, ErrServiceNotRunning
 	}
 
 	endpoint, err := url.Parse(strings.TrimSpace(matches[1]))
@@ -1114,12 +1321,14 @@ func (m *Manager) statusEndpoint(ctx context.Context) (*url.URL, error) {
 }
 
 // invokeFoundry executes the foundry command-line tool with the given arguments
-// and returns the combined stdout and stderr output.
-func (m *Manager) invokeFoundry(ctx context.Context, args string) (string, error) {
-	cmdArgs := strings.Split(args, " ")
-	cmd := exec.CommandContext(ctx, "foundry", cmdArgs...)
-	bytes, err := cmd.CombinedOutput()
-	return string(bytes), err
+// through the ServiceLauncher and returns its output. The ServiceLauncher must
+// implement CommandRunner.
+func (m *Manager) invokeFoundry(ctx context.Context, args ...string) (string, error) {
+	runner, ok := m.launcher.(CommandRunner)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrCommandsNotSupported, m.launcher)
+	}
+	return runner.Run(ctx, args...)
 }
 
 // GetVersion extracts the version number from a model ID that follows the format "name:version".
@@ -1156,6 +1365,28 @@ func matchAliasOrId(aliasOrModelID string) func(modelInfo ModelInfo) bool {
 	}
 }
 
+// newClient creates the HTTP client used to communicate with the Foundry Local service.
+// It is based on the client and transport configured with WithHTTPClient and WithTransport.
+// The transport is always wrapped by sdkRoundTripper to set the SDK's User-Agent,
+// and the interceptors configured with WithInterceptors are composed around it.
+func (m *Manager) newClient() *http.Client {
+	client := &http.Client{}
+	if m.baseClient != nil {
+		c := *m.baseClient
+		client = &c
+	}
+
+	transport := m.transport
+	if transport == nil {
+		transport = client.Transport
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	client.Transport = intercept(&sdkRoundTripper{transport}, m.interceptors)
+	return client
+}
+
 // ensureSuccessStatusCode checks if an HTTP response has a success status code (2xx).
 // Returns true for status codes in the range 200-299, false otherwise.
 func ensureSuccessStatusCode(resp *http.Response) bool {
@@ -356,9 +356,8 @@ func TestListCatalogModel(t *testing.T) {
 		t.Fatalf("failed to parse service URL: %v", err)
 	}
 
-	m := NewManager()
+	m := NewManager(WithHTTPClient(srv.Client()))
 	m.serviceURL = serviceURL
-	m.client = srv.Client()
 
 	result, err := m.ListCatalogModels(t.Context())
 	if err != nil {
@@ -488,9 +487,8 @@ func TestGetModelInfo(t *testing.T) {
 				t.Fatalf("failed to parse service URL: %v", err)
 			}
 
-			m := NewManager()
+			m := NewManager(WithHTTPClient(srv.Client()))
 			m.serviceURL = serviceURL
-			m.client = srv.Client()
 
 			result, err := m.GetModelInfo(t.Context(), tc.aliasOrModelID, tc.device)
 			if got, want := err, tc.err; !errors.Is(got, want) {
@@ -516,9 +514,8 @@ func TestListCachedModels(t *testing.T) {
 		t.Fatalf("failed to parse service URL: %v", err)
 	}
 
-	m := NewManager()
+	m := NewManager(WithHTTPClient(srv.Client()))
 	m.serviceURL = serviceURL
-	m.client = srv.Client()
 
 	local, err := m.ListCachedModels(t.Context())
 	if err != nil {
@@ -566,9 +563,8 @@ func TestListLoadedModels(t *testing.T) {
 				t.Fatalf("failed to parse service URL: %v", err)
 			}
 
-			m := NewManager()
+			m := NewManager(WithHTTPClient(srv.Client()))
 			m.serviceURL = serviceURL
-			m.client = srv.Client()
 
 			result, err := m.ListLoadedModels(t.Context())
 			if err != nil {
@@ -623,9 +619,8 @@ func TestDownloadModel(t *testing.T) {
 				t.Fatalf("failed to parse service URL: %v", err)
 			}
 
-			m := NewManager()
+			m := NewManager(WithHTTPClient(srv.Client()))
 			m.serviceURL = serviceURL
-			m.client = srv.Client()
 
 			result, err := m.DownloadModel(t.Context(), tc.modelID, nil)
 			if err != nil {
@@ -655,9 +650,8 @@ func TestDownloadModelCached(t *testing.T) {
 		t.Fatalf("failed to parse service URL: %v", err)
 	}
 
-	m := NewManager()
+	m := NewManager(WithHTTPClient(srv.Client()))
 	m.serviceURL = serviceURL
-	m.client = srv.Client()
 
 	cached, err := m.DownloadModel(t.Context(), "model-2", nil)
 	if err != nil {
@@ -678,7 +672,6 @@ func TestDownloadModelCached(t *testing.T) {
 	}
 
 	m.serviceURL = serviceURL
-	m.client = srv2.Client()
 
 	forced, err := m.DownloadModel(t.Context(), "model-2", nil, WithForceDownload())
 	if err != nil {
@@ -708,9 +701,8 @@ func TestDownloadModelWithProgressDownload(t *testing.T) {
 		t.Fatalf("failed to parse service URL: %v", err)
 	}
 
-	m := NewManager()
+	m := NewManager(WithHTTPClient(srv.Client()))
 	m.serviceURL = serviceURL
-	m.client = srv.Client()
 
 	progressChan, err := m.DownloadModelWithProgress(t.Context(), "model-3", nil)
 	if err != nil {
@@ -760,9 +752,8 @@ func TestDownloadModelWithProgressError(t *testing.T) {
 		t.Fatalf("failed to parse service URL: %v", err)
 	}
 
-	m := NewManager()
+	m := NewManager(WithHTTPClient(srv.Client()))
 	m.serviceURL = serviceURL
-	m.client = srv.Client()
 
 	progressChan, err := m.DownloadModelWithProgress(t.Context(), "model-3", nil)
 	if err != nil {
@@ -798,9 +789,8 @@ func TestLoadModelEPOverride(t *testing.T) {
 		t.Fatalf("failed to parse service URL: %v", err)
 	}
 
-	m := NewManager()
+	m := NewManager(WithHTTPClient(srv.Client()))
 	m.serviceURL = serviceURL
-	m.client = srv.Client()
 
 	// After ListCatalogModels runs, EPOverride for generic-gpu will be "cuda"
 	// First call ensures the override is applied
@@ -829,9 +819,8 @@ func TestLoadModelError(t *testing.T) {
 		t.Fatalf("failed to parse service URL: %v", err)
 	}
 
-	m := NewManager()
+	m := NewManager(WithHTTPClient(srv.Client()))
 	m.serviceURL = serviceURL
-	m.client = srv.Client()
 
 	_, err = m.LoadModel(t.Context(), "model-3", nil)
 	if err == nil {
@@ -858,9 +847,8 @@ func TestUnloadModel(t *testing.T) {
 		t.Fatalf("failed to parse service URL: %v", err)
 	}
 
-	m := NewManager()
+	m := NewManager(WithHTTPClient(srv.Client()))
 	m.serviceURL = serviceURL
-	m.client = srv.Client()
 
 	if got, want := m.UnloadModel(t.Context(), modelID, nil, false), error(nil); got != want {
 		t.Errorf("got error %v, want %v", got, want)
@@ -914,9 +902,8 @@ func TestIsModelUpgradeable(t *testing.T) {
 				t.Fatalf("failed to parse service URL: %v", err)
 			}
 
-			m := NewManager()
+			m := NewManager(WithHTTPClient(srv.Client()))
 			m.serviceURL = serviceURL
-			m.client = srv.Client()
 
 			got, err := m.IsModelUpgradable(t.Context(), tc.modelID, nil)
 			if err != nil {
@@ -980,9 +967,8 @@ func TestUpgradeModel(t *testing.T) {
 				t.Fatalf("failed to parse service URL: %v", err)
 			}
 
-			m := NewManager()
+			m := NewManager(WithHTTPClient(srv.Client()))
 			m.serviceURL = serviceURL
-			m.client = srv.Client()
 
 			mi, err := m.UpgradeModel(t.Context(), tc.modelID, nil, "")
 			if got, want := err, tc.err; !errors.Is(got, want) {
@@ -1005,9 +991,8 @@ func TestGetCacheLocation(t *testing.T) {
 		t.Fatalf("failed to parse service URL: %v", err)
 	}
 
-	m := NewManager()
+	m := NewManager(WithHTTPClient(srv.Client()))
 	m.serviceURL = serviceURL
-	m.client = srv.Client()
 
 	path, err := m.GetCacheLocation(t.Context())
 	if err != nil {
@@ -1017,3 +1002,86 @@ func TestGetCacheLocation(t *testing.T) {
 		t.Errorf("got cache location %q, want %q", got, want)
 	}
 }
+
+// TestConnect verifies Connect attaches to a running service without using the
+// ServiceLauncher and fails when the readiness probe does not succeed.
+func TestConnect(t *testing.T) {
+	tests := []struct {
+		name    string
+		routes  []route
+		wantErr bool
+	}{
+		{
+			name: "connect_ready",
+			routes: []route{
+				mockJSON("/openai/status", json.RawMessage(`{"modelDirPath": "/models"}`)),
+				mockCatalog(true),
+				mockLoadedModels("model-2-npu:1"),
+			},
+		},
+		{
+			name:    "connect_not_ready",
+			routes:  []route{},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(newHandler(tc.routes...))
+			defer srv.Close()
+
+			launcher := &fakeLauncher{}
+			m, err := Connect(t.Context(), srv.URL, WithServiceLauncher(launcher))
+			if got, want := err != nil, tc.wantErr; got != want {
+				t.Fatalf("got error %v, want error %t", err, want)
+			}
+			if got, want := launcher.starts, 0; got != want {
+				t.Errorf("got %d launcher starts, want %d", got, want)
+			}
+			if tc.wantErr {
+				return
+			}
+
+			loaded, err := m.ListLoadedModels(t.Context())
+			if err != nil {
+				t.Fatalf("failed to list loaded models: %v", err)
+			}
+			if got, want := len(loaded), 1; got != want {
+				t.Fatalf("got %d loaded models, want %d", got, want)
+			}
+
+			if err := m.StopService(t.Context()); err != nil {
+				t.Fatalf("failed to stop service: %v", err)
+			}
+			if got, want := launcher.stops, 0; got != want {
+				t.Errorf("got %d launcher stops, want %d", got, want)
+			}
+		})
+	}
+}
+
+// TestConnectOptions verifies Connect leaves the caller's options untouched and
+// that a nil endpoint is ignored.
+func TestConnectOptions(t *testing.T) {
+	srv := httptest.NewServer(newHandler(mockJSON("/openai/status", json.RawMessage(`{}`))))
+	defer srv.Close()
+
+	// The spare capacity of opts must not be overwritten with the endpoint option.
+	opts := []ManagerOption{WithHTTPClient(srv.Client()), WithOwnership(OwnershipExclusive)}
+	if _, err := Connect(t.Context(), srv.URL, opts[:1]...); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	var m Manager
+	opts[1](&m)
+	if got, want := m.ownership, OwnershipExclusive; got != want {
+		t.Errorf("got ownership %v, want %v", got, want)
+	}
+	if m.endpoint != nil {
+		t.Errorf("got endpoint %v, want caller's option unchanged", m.endpoint)
+	}
+
+	if got := NewManager(WithEndpoint(nil)).endpoint; got != nil {
+		t.Errorf("got endpoint %v, want nil", got)
+	}
+}
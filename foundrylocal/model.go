@@ -2,7 +2,13 @@ package foundrylocal
 
 // PromptTemplate defines the format for prompts used with a model.
 // Different models may require different prompt formatting to work optimally.
+// Each template contains the placeholder {Content}, which is replaced with the
+// content of a message. Use Render to format a conversation for Complete.
 type PromptTemplate struct {
+	// System is the template for system messages.
+	System string `json:"system,omitempty"`
+	// User is the template for user messages that are followed by other messages.
+	User string `json:"user,omitempty"`
 	// Assistant is the template for assistant responses.
 	Assistant string `json:"assistant"`
 	// Prompt is the template for user prompts.
@@ -0,0 +1,73 @@
+package foundrylocal
+
+import (
+	"context"
+	"os"
+	"os/exec"
+)
+
+// OpenAIConfig contains the settings an OpenAI-compatible client needs to use a
+// model served by Foundry Local.
+type OpenAIConfig struct {
+	// BaseURL is the base URL of the OpenAI-compatible API, for example
+	// "http://127.0.0.1:5273/v1".
+	BaseURL string
+	// APIKey is the API key clients send to the service.
+	APIKey string
+	// Model is the resolved model ID to pass as the model of requests.
+	Model string
+}
+
+// OpenAIConfig returns the settings for OpenAI-compatible clients to use the model
+// identified by aliasOrModelID. The alias is resolved like in GetModelInfo, and the
+// service is started if it is not running.
+//
+// Example:
+//
+//	cfg, err := manager.OpenAIConfig(ctx, "qwen2.5-0.5b", nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	client := openai.NewClient(option.WithBaseURL(cfg.BaseURL), option.WithAPIKey(cfg.APIKey))
+func (m *Manager) OpenAIConfig(ctx context.Context, aliasOrModelID string, device *DeviceType) (OpenAIConfig, error) {
+	if err := m.StartService(ctx); err != nil {
+		return OpenAIConfig{}, err
+	}
+	modelInfo, err := m.GetModelInfo(ctx, aliasOrModelID, device)
+	if err != nil {
+		return OpenAIConfig{}, err
+	}
+	return OpenAIConfig{
+		BaseURL: m.Endpoint().String(),
+		APIKey:  m.ApiKey,
+		Model:   modelInfo.ID,
+	}, nil
+}
+
+// Env returns the settings as OPENAI_BASE_URL, OPENAI_API_KEY, and OPENAI_MODEL
+// environment variables in "key=value" form.
+func (c OpenAIConfig) Env() []string {
+	return []string{
+		"OPENAI_BASE_URL=" + c.BaseURL,
+		"OPENAI_API_KEY=" + c.APIKey,
+		"OPENAI_MODEL=" + c.Model,
+	}
+}
+
+// ApplyEnv adds the environment variables returned by Env to cmd, so that a child
+// process can use the same model. If cmd.Env is nil, the environment of the current
+// process is used as a base. Existing values of the variables are overridden.
+//
+// Example:
+//
+//	cmd := exec.CommandContext(ctx, "python", "agent.py")
+//	cfg.ApplyEnv(cmd)
+//	if err := cmd.Run(); err != nil {
+//		log.Fatal(err)
+//	}
+func (c OpenAIConfig) ApplyEnv(cmd *exec.Cmd) {
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, c.Env()...)
+}
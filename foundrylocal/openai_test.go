@@ -0,0 +1,102 @@
+package foundrylocal
+
+import (
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"os/exec"
+	"slices"
+	"testing"
+)
+
+// TestOpenAIConfig verifies that OpenAIConfig resolves the model and reports the
+// endpoint and API key of the Manager.
+func TestOpenAIConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		alias     string
+		wantModel string
+		wantErr   error
+	}{
+		{
+			name:      "alias",
+			alias:     "model-2",
+			wantModel: "model-2-npu:2",
+		},
+		{
+			name:      "model_id",
+			alias:     "model-1-generic-cpu:1",
+			wantModel: "model-1-generic-cpu:1",
+		},
+		{
+			name:    "unknown_model",
+			alias:   "model-42",
+			wantErr: ErrModelNotInCatalog,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(newHandler(mockCatalog(true)))
+			defer srv.Close()
+			serviceURL, err := url.Parse(srv.URL)
+			if err != nil {
+				t.Fatalf("failed to parse service URL: %v", err)
+			}
+
+			m := NewManager(WithHTTPClient(srv.Client()))
+			m.serviceURL = serviceURL
+			m.ApiKey = "test-key"
+
+			cfg, err := m.OpenAIConfig(t.Context(), tc.alias, nil)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+
+			want := OpenAIConfig{
+				BaseURL: srv.URL + "/v1",
+				APIKey:  "test-key",
+				Model:   tc.wantModel,
+			}
+			if got := cfg; got != want {
+				t.Errorf("got config %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+// TestOpenAIConfigEnv verifies the environment variables passed to child processes.
+func TestOpenAIConfigEnv(t *testing.T) {
+	cfg := OpenAIConfig{BaseURL: "http://127.0.0.1:5273/v1", APIKey: "key", Model: "model-1-generic-cpu:1"}
+	want := []string{
+		"OPENAI_BASE_URL=http://127.0.0.1:5273/v1",
+		"OPENAI_API_KEY=key",
+		"OPENAI_MODEL=model-1-generic-cpu:1",
+	}
+	if got := cfg.Env(); !slices.Equal(got, want) {
+		t.Errorf("got env %v, want %v", got, want)
+	}
+
+	t.Setenv("FOUNDRY_TEST_INHERITED", "1")
+	cmd := exec.Command("true")
+	cfg.ApplyEnv(cmd)
+	if !slices.Contains(cmd.Env, "FOUNDRY_TEST_INHERITED=1") {
+		t.Errorf("got env %v, want inherited environment", cmd.Env)
+	}
+	if got := cmd.Env[len(cmd.Env)-len(want):]; !slices.Equal(got, want) {
+		t.Errorf("got env suffix %v, want %v", got, want)
+	}
+
+	cmd = exec.Command("true")
+	cmd.Env = []string{"PATH=/bin"}
+	cfg.ApplyEnv(cmd)
+	if got, want := cmd.Env, append([]string{"PATH=/bin"}, want...); !slices.Equal(got, want) {
+		t.Errorf("got env %v, want %v", got, want)
+	}
+}
@@ -2,6 +2,8 @@ package foundrylocal
 
 import (
 	"log/slog"
+	"net/http"
+	"net/url"
 	"runtime"
 	"time"
 )
@@ -61,6 +63,37 @@ func WithLoadTimeout(timeout time.Duration) LoadModelOption {
 // LoadModelOption configures model loading operations.
 type LoadModelOption func(*loadModelConfig)
 
+// ShutdownOption configures Manager.Shutdown.
+type ShutdownOption func(*shutdownConfig)
+
+type shutdownConfig struct {
+	stopService bool
+	force       bool
+}
+
+// WithStopService makes Shutdown stop the Foundry Local service after unloading
+// the Manager's models. StopService's ownership rules apply.
+//
+// Example:
+//
+//	err := manager.Shutdown(ctx, foundrylocal.WithStopService())
+func WithStopService() ShutdownOption {
+	return func(cfg *shutdownConfig) {
+		cfg.stopService = true
+	}
+}
+
+// WithForceUnload makes Shutdown unload models even if they are currently in use.
+//
+// Example:
+//
+//	err := manager.Shutdown(ctx, foundrylocal.WithForceUnload())
+func WithForceUnload() ShutdownOption {
+	return func(cfg *shutdownConfig) {
+		cfg.force = true
+	}
+}
+
 // ManagerOption configures Manager instances during creation.
 type ManagerOption func(*Manager)
 
@@ -103,3 +136,173 @@ func WithLogger(logger *slog.Logger) ManagerOption {
 		m.Logger = logger
 	}
 }
+
+// WithServiceLauncher sets the ServiceLauncher the Manager uses to start, stop,
+// and query the Foundry Local service. By default, a CLILauncher invoking
+// "foundry" from PATH is used.
+//
+// Example:
+//
+//	manager := foundrylocal.NewManager(
+//		foundrylocal.WithServiceLauncher(&foundrylocal.CLILauncher{Path: "/opt/foundry/bin/foundry"}))
+func WithServiceLauncher(launcher ServiceLauncher) ManagerOption {
+	return func(m *Manager) {
+		m.launcher = launcher
+	}
+}
+
+// WithEndpoint attaches the Manager to an already running Foundry Local service
+// at the given base URL, such as "http://127.0.0.1:5273". The Manager does not
+// use its ServiceLauncher in this mode: StartService probes the endpoint for
+// readiness and StopService leaves the service running. A nil endpoint is ignored.
+//
+// The runtime version is not detected in this mode, so it is not checked against
+// MinRuntimeVersion and models are not checked for compatibility with the runtime.
+//
+// Example:
+//
+//	endpoint, _ := url.Parse("http://127.0.0.1:5273")
+//	manager := foundrylocal.NewManager(foundrylocal.WithEndpoint(endpoint))
+func WithEndpoint(endpoint *url.URL) ManagerOption {
+	return func(m *Manager) {
+		if endpoint == nil {
+			return
+		}
+		u := *endpoint
+		m.endpoint = &u
+	}
+}
+
+// WithAutoRecovery enables automatic service recovery. If the Foundry Local service
+// cannot be reached, for example because it crashed or was restarted on a different
+// port, the Manager re-resolves the service endpoint, restarts the service if needed,
+// and retries idempotent operations once. Recovery attempts are logged with the
+// Manager's Logger.
+//
+// Example:
+//
+//	manager := foundrylocal.NewManager(foundrylocal.WithAutoRecovery())
+func WithAutoRecovery() ManagerOption {
+	return func(m *Manager) {
+		m.autoRecover = true
+	}
+}
+
+// Ownership controls whether StopService stops a Foundry Local service
+// that this Manager did not start.
+type Ownership int
+
+const (
+	// OwnershipShared treats the service as shared with other applications.
+	// StopService only stops the service if this Manager started it.
+	// This is the default.
+	OwnershipShared Ownership = iota
+	// OwnershipExclusive treats the service as owned by this Manager.
+	// StopService always stops the service, even if another application started it.
+	OwnershipExclusive
+)
+
+// WithOwnership sets whether StopService may stop a Foundry Local service that
+// was already running when this Manager attached to it.
+// The default is OwnershipShared.
+//
+// Example:
+//
+//	manager := foundrylocal.NewManager(foundrylocal.WithOwnership(foundrylocal.OwnershipExclusive))
+func WithOwnership(ownership Ownership) ManagerOption {
+	return func(m *Manager) {
+		m.ownership = ownership
+	}
+}
+
+// WithRetryPolicy configures retries for idempotent GET requests to the Foundry Local
+// service, for example when the runtime is temporarily busy loading a model. By default,
+// requests are not retried. See DefaultRetryPolicy for a reasonable starting point.
+//
+// Example:
+//
+//	manager := foundrylocal.NewManager(
+//		foundrylocal.WithRetryPolicy(foundrylocal.DefaultRetryPolicy()))
+func WithRetryPolicy(policy RetryPolicy) ManagerOption {
+	return func(m *Manager) {
+		m.retryPolicy = policy
+	}
+}
+
+// WithHTTPClient sets the HTTP client the Manager uses to communicate with the
+// Foundry Local service. The client is copied, and its transport is wrapped to
+// add the SDK's User-Agent header. The client is kept across StopService and
+// StartService cycles. By default, a client without a timeout using
+// http.DefaultTransport is used, and requests are bounded by the Manager's
+// Timeouts instead. A timeout set on client applies in addition to them.
+//
+// Example:
+//
+//	manager := foundrylocal.NewManager(
+//		foundrylocal.WithHTTPClient(&http.Client{Timeout: 30 * time.Minute}))
+func WithHTTPClient(client *http.Client) ManagerOption {
+	return func(m *Manager) {
+		m.baseClient = client
+	}
+}
+
+// WithTransport sets the http.RoundTripper the Manager uses to communicate with the
+// Foundry Local service, for example to add instrumentation or limit connections.
+// The transport is wrapped to add the SDK's User-Agent header and takes precedence
+// over the transport of a client set with WithHTTPClient.
+//
+// Example:
+//
+//	transport := &http.Transport{MaxConnsPerHost: 4}
+//	manager := foundrylocal.NewManager(foundrylocal.WithTransport(transport))
+func WithTransport(transport http.RoundTripper) ManagerOption {
+	return func(m *Manager) {
+		m.transport = transport
+	}
+}
+
+// WithTimeouts sets the timeouts for requests to the Foundry Local service by
+// class of operation, so that a hung runtime cannot block callers indefinitely.
+// The caller's context is always honored as well. By default, DefaultTimeouts
+// is used.
+//
+// Example:
+//
+//	timeouts := foundrylocal.DefaultTimeouts()
+//	timeouts.Metadata = 5 * time.Second
+//	manager := foundrylocal.NewManager(foundrylocal.WithTimeouts(timeouts))
+func WithTimeouts(timeouts Timeouts) ManagerOption {
+	return func(m *Manager) {
+		m.timeouts = timeouts
+	}
+}
+
+// WithInterceptors adds interceptors for the HTTP requests the Manager sends to the
+// Foundry Local service. Interceptors run in the order they are added: the first one
+// sees each request first and its response last. They are composed around the
+// transport that sets the SDK's User-Agent header. WithInterceptors can be used
+// multiple times.
+//
+// Example:
+//
+//	manager := foundrylocal.NewManager(
+//		foundrylocal.WithInterceptors(correlationIDs, auditLog))
+func WithInterceptors(interceptors ...Interceptor) ManagerOption {
+	return func(m *Manager) {
+		m.interceptors = append(m.interceptors, interceptors...)
+	}
+}
+
+// WithValidationMode sets how requests to a model are validated against the model's
+// limits before they are sent, for example max_tokens against the model's maximum
+// output tokens and tools against its support for tool calling. Violations are
+// reported as a ValidationError. The default is ValidationLenient.
+//
+// Example:
+//
+//	manager := foundrylocal.NewManager(foundrylocal.WithValidationMode(foundrylocal.ValidationStrict))
+func WithValidationMode(mode ValidationMode) ManagerOption {
+	return func(m *Manager) {
+		m.validationMode = mode
+	}
+}
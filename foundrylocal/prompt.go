@@ -0,0 +1,94 @@
+package foundrylocal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contentPlaceholder is replaced with the content of a message in a PromptTemplate.
+const contentPlaceholder = "{Content}"
+
+// Render formats messages in the model's native prompt format, so they can be sent
+// with Complete. Messages are rendered with the template of their role and joined
+// by newlines:
+//
+//   - System messages use the System template. If the template has none, their
+//     content is prepended to the next user message.
+//   - User messages use the User template, except for a final user message, which
+//     uses the Prompt template so that the model answers it.
+//   - Assistant messages use the Assistant template. A final assistant message is
+//     rendered without the part of the template that follows its content, so the
+//     model continues it. This allows prefilling the beginning of an answer.
+//
+// Render returns an error if a required template is missing or has no {Content}
+// placeholder, or if messages contain tool messages.
+//
+// Example:
+//
+//	info, err := manager.GetModelInfo(ctx, "phi-3.5-mini", nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	prompt, err := info.PromptTemplate.Render([]foundrylocal.ChatMessage{
+//		foundrylocal.UserMessage("Translate to French: cheese"),
+//		foundrylocal.AssistantMessage("fromage"),
+//		foundrylocal.UserMessage("Translate to French: bread"),
+//	})
+func (t *PromptTemplate) Render(messages []ChatMessage) (string, error) {
+	if t == nil {
+		return "", fmt.Errorf("model has no prompt template")
+	}
+	if len(messages) == 0 {
+		return "", fmt.Errorf("no messages to render")
+	}
+
+	var (
+		parts  []string
+		system []string
+	)
+	for i, message := range messages {
+		last := i == len(messages)-1
+		content := message.Content
+		var (
+			name     string
+			template string
+		)
+		switch message.Role {
+		case RoleSystem:
+			if t.System == "" {
+				system = append(system, content)
+				continue
+			}
+			name, template = "system", t.System
+		case RoleUser:
+			if len(system) > 0 {
+				content = strings.Join(append(system, content), "\n\n")
+				system = nil
+			}
+			name, template = "user", t.User
+			if last {
+				name, template = "prompt", t.Prompt
+			}
+		case RoleAssistant:
+			name, template = "assistant", t.Assistant
+		default:
+			return "", fmt.Errorf("cannot render message %d with role %q", i, message.Role)
+		}
+
+		if template == "" {
+			return "", fmt.Errorf("prompt template has no %s template", name)
+		}
+		before, after, ok := strings.Cut(template, contentPlaceholder)
+		if !ok {
+			return "", fmt.Errorf("%s template has no %s placeholder", name, contentPlaceholder)
+		}
+		if last && message.Role == RoleAssistant {
+			after = ""
+		}
+		parts = append(parts, before+content+after)
+	}
+	if len(system) > 0 {
+		return "", fmt.Errorf("prompt template has no system template")
+	}
+	return strings.Join(parts, "\n"), nil
+}
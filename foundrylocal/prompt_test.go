@@ -0,0 +1,99 @@
+package foundrylocal
+
+import "testing"
+
+// TestRender verifies that conversations are formatted with a model's prompt template.
+func TestRender(t *testing.T) {
+	phi := &PromptTemplate{
+		System:    "<|system|>\n{Content}<|end|>",
+		User:      "<|user|>\n{Content}<|end|>",
+		Assistant: "<|assistant|>\n{Content}<|end|>",
+		Prompt:    "<|user|>\n{Content}<|end|>\n<|assistant|>",
+	}
+	minimal := &PromptTemplate{
+		Assistant: "<|assistant|>{Content}<|end|>",
+		Prompt:    "<|user|>{Content}<|end|><|assistant|>",
+	}
+
+	tests := []struct {
+		name     string
+		template *PromptTemplate
+		messages []ChatMessage
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "single_prompt",
+			template: phi,
+			messages: []ChatMessage{UserMessage("Hello")},
+			want:     "<|user|>\nHello<|end|>\n<|assistant|>",
+		},
+		{
+			name:     "few_shot",
+			template: phi,
+			messages: []ChatMessage{
+				SystemMessage("Translate to French."),
+				UserMessage("cheese"),
+				AssistantMessage("fromage"),
+				UserMessage("bread"),
+			},
+			want: "<|system|>\nTranslate to French.<|end|>\n" +
+				"<|user|>\ncheese<|end|>\n" +
+				"<|assistant|>\nfromage<|end|>\n" +
+				"<|user|>\nbread<|end|>\n<|assistant|>",
+		},
+		{
+			name:     "prefill",
+			template: phi,
+			messages: []ChatMessage{UserMessage("List three colors as JSON."), AssistantMessage(`["`)},
+			want:     "<|user|>\nList three colors as JSON.<|end|>\n<|assistant|>\n[\"",
+		},
+		{
+			name:     "system_merged_into_prompt",
+			template: minimal,
+			messages: []ChatMessage{SystemMessage("Be brief."), UserMessage("Hello")},
+			want:     "<|user|>Be brief.\n\nHello<|end|><|assistant|>",
+		},
+		{
+			name:     "missing_user_template",
+			template: minimal,
+			messages: []ChatMessage{UserMessage("Hello"), AssistantMessage("Hi"), UserMessage("Bye")},
+			wantErr:  "prompt template has no user template",
+		},
+		{
+			name:     "missing_placeholder",
+			template: &PromptTemplate{Prompt: "<|user|>"},
+			messages: []ChatMessage{UserMessage("Hello")},
+			wantErr:  "prompt template has no {Content} placeholder",
+		},
+		{
+			name:     "tool_message",
+			template: phi,
+			messages: []ChatMessage{ToolMessage("call-1", "{}")},
+			wantErr:  `cannot render message 0 with role "tool"`,
+		},
+		{
+			name:     "no_template",
+			messages: []ChatMessage{UserMessage("Hello")},
+			wantErr:  "model has no prompt template",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.template.Render(tc.messages)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("got error %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if got != tc.want {
+				t.Errorf("got prompt\n%q\nwant\n%q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package recorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// cassetteVersion is the version of the cassette file format.
+const cassetteVersion = 1
+
+// Cassette is a recorded sequence of HTTP interactions. Cassettes are stored as
+// indented JSON so they can be reviewed and edited by hand.
+type Cassette struct {
+	// Version is the version of the cassette file format.
+	Version int `json:"version"`
+	// Interactions contains the recorded interactions in the order they occurred.
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and the response it received.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request. The host of the request URL is not
+// recorded, because Foundry Local listens on a dynamic port.
+type Request struct {
+	// Method is the HTTP method.
+	Method string `json:"method"`
+	// Path is the escaped URL path.
+	Path string `json:"path"`
+	// Query is the encoded URL query, without the leading '?'.
+	Query string `json:"query,omitempty"`
+	// Header contains the request headers.
+	Header http.Header `json:"header,omitempty"`
+	// Body is the request body.
+	Body string `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response. Streamed responses, such as download
+// progress, are recorded in full and replayed at once.
+type Response struct {
+	// StatusCode is the HTTP status code.
+	StatusCode int `json:"statusCode"`
+	// Header contains the response headers.
+	Header http.Header `json:"header,omitempty"`
+	// Body is the response body.
+	Body string `json:"body,omitempty"`
+}
+
+// LoadCassette reads the cassette stored at path.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	if c.Version != cassetteVersion {
+		return nil, fmt.Errorf("unsupported cassette version %d in %s", c.Version, path)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to path, creating parent directories as needed.
+func (c *Cassette) Save(path string) error {
+	c.Version = cassetteVersion
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,328 @@
+// Package recorder provides an http.RoundTripper that records the traffic between
+// a foundrylocal.Manager and the Foundry Local service to cassette files and
+// replays it later. This allows tests to exercise real runtime interactions on
+// machines where Foundry Local is not installed, such as Linux CI runners.
+//
+// Record a cassette once against a real service:
+//
+//	rec, err := recorder.New("testdata/load.json", recorder.ModeRecord)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	manager := foundrylocal.NewManager(foundrylocal.WithTransport(rec))
+//	// ... use the manager ...
+//	if err := rec.Save(); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// Replay it in tests, where the service endpoint's host and port are ignored:
+//
+//	rec, err := recorder.New("testdata/load.json", recorder.ModeReplay)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	manager, err := foundrylocal.Connect(ctx, "http://127.0.0.1:5273", foundrylocal.WithTransport(rec))
+//
+// Authorization headers and the token of download requests are redacted before
+// interactions are recorded or matched.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Mode selects whether a Recorder records or replays interactions.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette. Requests that do not match
+	// a recorded interaction fail with ErrNoInteraction.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the service and records the interactions.
+	ModeRecord
+)
+
+// Redacted replaces secrets in recorded interactions.
+const Redacted = "REDACTED"
+
+// ErrNoInteraction is returned in replay mode when no unused recorded interaction
+// matches a request.
+var ErrNoInteraction = errors.New("no recorded interaction matches request")
+
+// redactedHeaders lists the request headers whose values are replaced by Redacted.
+var redactedHeaders = []string{"Authorization", "Api-Key"}
+
+// redactedFields lists the JSON request body fields whose values are replaced by
+// Redacted, such as the token of a foundrylocal.DownloadRequest.
+var redactedFields = []string{"token"}
+
+// Matcher reports whether a request matches a recorded request.
+type Matcher func(req, recorded Request) bool
+
+// DefaultMatcher matches requests by method, path, query, and body. Query
+// parameters match regardless of their order, and JSON bodies match if they
+// are semantically equal.
+func DefaultMatcher(req, recorded Request) bool {
+	return req.Method == recorded.Method &&
+		req.Path == recorded.Path &&
+		equalQuery(req.Query, recorded.Query) &&
+		equalBody(req.Body, recorded.Body)
+}
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithTransport sets the transport used to send requests in record mode.
+// By default, http.DefaultTransport is used.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithMatcher sets how requests are matched against recorded interactions in
+// replay mode. By default, DefaultMatcher is used.
+//
+// Example:
+//
+//	// Ignore request bodies.
+//	rec, err := recorder.New(path, recorder.ModeReplay, recorder.WithMatcher(
+//		func(req, recorded recorder.Request) bool {
+//			return req.Method == recorded.Method && req.Path == recorded.Path
+//		}))
+func WithMatcher(matcher Matcher) Option {
+	return func(r *Recorder) {
+		r.matcher = matcher
+	}
+}
+
+// WithRedactor adds a function that removes additional secrets from interactions.
+// Redactors run after the built-in redaction, before interactions are recorded and
+// before requests are matched in replay mode, where the response is empty.
+func WithRedactor(redact func(*Interaction)) Option {
+	return func(r *Recorder) {
+		r.redactors = append(r.redactors, redact)
+	}
+}
+
+// Recorder is an http.RoundTripper that records or replays HTTP interactions.
+// It is safe for concurrent use.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	matcher   Matcher
+	redactors []func(*Interaction)
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// New creates a Recorder for the cassette at path. In replay mode, the cassette
+// must exist. In record mode, a new cassette is written to path by Save.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		matcher:   DefaultMatcher,
+		cassette:  &Cassette{Version: cassetteVersion},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if mode == ModeReplay {
+		c, err := LoadCassette(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = c
+		r.used = make([]bool, len(c.Interactions))
+	}
+	return r, nil
+}
+
+// RoundTrip records or replays the interaction for req.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			Path:   req.URL.EscapedPath(),
+			Query:  req.URL.RawQuery,
+			Header: req.Header.Clone(),
+			Body:   string(body),
+		},
+	}
+	r.redact(&interaction)
+
+	if r.mode == ModeReplay {
+		return r.replay(req, interaction.Request)
+	}
+	return r.record(req, body, interaction)
+}
+
+// Save writes the recorded interactions to the cassette file. It does nothing
+// in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode == ModeReplay {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Save(r.path)
+}
+
+// Interactions returns a copy of the recorded or loaded interactions.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// replay returns the response of the first unused interaction that matches recorded.
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !r.matcher(recorded, interaction.Request) {
+			continue
+		}
+		r.used[i] = true
+		resp := interaction.Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+			StatusCode:    resp.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        resp.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(resp.Body)),
+			ContentLength: int64(len(resp.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL.RequestURI())
+}
+
+// record sends req and records the interaction. The response body is read in full,
+// so streamed responses are delivered to the caller at once.
+func (r *Recorder) record(req *http.Request, body []byte, interaction Interaction) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := r.transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	interaction.Response = Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       string(respBody),
+	}
+	for _, redact := range r.redactors {
+		redact(&interaction)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	resp.ContentLength = int64(len(respBody))
+	return resp, nil
+}
+
+// redact removes secrets from the request of interaction.
+func (r *Recorder) redact(interaction *Interaction) {
+	for _, name := range redactedHeaders {
+		if interaction.Request.Header.Get(name) != "" {
+			interaction.Request.Header.Set(name, Redacted)
+		}
+	}
+	interaction.Request.Body = redactBody(interaction.Request.Body)
+	if r.mode == ModeReplay {
+		for _, redact := range r.redactors {
+			redact(interaction)
+		}
+	}
+}
+
+// redactBody replaces the values of redactedFields in a JSON object body.
+// Other bodies are returned unchanged.
+func redactBody(body string) string {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal([]byte(body), &fields) != nil {
+		return body
+	}
+	var changed bool
+	for key, value := range fields {
+		for _, field := range redactedFields {
+			if strings.EqualFold(key, field) && string(value) != `""` && string(value) != "null" {
+				fields[key] = json.RawMessage(`"` + Redacted + `"`)
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return body
+	}
+	redacted, err := json.Marshal(fields)
+	if err != nil {
+		return body
+	}
+	return string(redacted)
+}
+
+// readBody reads and closes the body of req.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return io.ReadAll(req.Body)
+}
+
+// equalQuery reports whether two encoded queries contain the same parameters.
+func equalQuery(a, b string) bool {
+	if a == b {
+		return true
+	}
+	va, errA := url.ParseQuery(a)
+	vb, errB := url.ParseQuery(b)
+	return errA == nil && errB == nil && reflect.DeepEqual(va, vb)
+}
+
+// equalBody reports whether two bodies are equal, comparing JSON bodies semantically.
+func equalBody(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var va, vb any
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
@@ -0,0 +1,204 @@
+package recorder
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/joergjo/go-foundry-local/foundrylocal"
+)
+
+// newService creates a test server that mimics the Foundry Local endpoints used
+// by a model download and load. The model is cached after it was downloaded.
+func newService(t *testing.T) *httptest.Server {
+	t.Helper()
+	catalog, err := json.Marshal([]foundrylocal.ModelInfo{{
+		ID:           "model-1-generic-cpu:1",
+		Alias:        "model-1",
+		ProviderType: "AzureFoundry",
+		URI:          "azureml://registries/azureml/models/model-1-generic-cpu/versions/1",
+		Version:      "1",
+		Runtime:      foundrylocal.Runtime{DeviceType: foundrylocal.DeviceTypeCPU, ExecutionProvider: "CPUExecutionProvider"},
+	}})
+	if err != nil {
+		t.Fatalf("failed to marshal catalog: %v", err)
+	}
+
+	var downloaded bool
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/openai/status":
+			w.Write([]byte(`{"modelDirPath": "/models"}`))
+		case "/foundry/list":
+			w.Write(catalog)
+		case "/openai/models":
+			if downloaded {
+				w.Write([]byte(`["model-1-generic-cpu:1"]`))
+			} else {
+				w.Write([]byte(`[]`))
+			}
+		case "/openai/download":
+			downloaded = true
+			w.Write([]byte("Total 50.00% Downloading model-1\n[DONE] All Completed\n{\"success\": true, \"errorMessage\": null}\n"))
+		case "/openai/load/model-1-generic-cpu:1":
+			w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+// useModel downloads and loads model-1 with the given transport.
+func useModel(t *testing.T, endpoint string, transport http.RoundTripper) foundrylocal.ModelInfo {
+	t.Helper()
+	m, err := foundrylocal.Connect(t.Context(), endpoint, foundrylocal.WithTransport(transport))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	if _, err := m.DownloadModel(t.Context(), "model-1", nil, foundrylocal.WithToken("secret-token")); err != nil {
+		t.Fatalf("failed to download model: %v", err)
+	}
+	model, err := m.LoadModel(t.Context(), "model-1", nil)
+	if err != nil {
+		t.Fatalf("failed to load model: %v", err)
+	}
+	return model
+}
+
+// TestRecordReplay verifies that interactions recorded against a service are
+// replayed without it and that secrets are redacted from the cassette.
+func TestRecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "load.json")
+
+	srv := newService(t)
+	rec, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("failed to create recorder: %v", err)
+	}
+	recorded := useModel(t, srv.URL, rec)
+	srv.Close()
+	if err := rec.Save(); err != nil {
+		t.Fatalf("failed to save cassette: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read cassette: %v", err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Errorf("cassette contains download token")
+	}
+	if !strings.Contains(string(data), Redacted) {
+		t.Errorf("cassette does not contain %q", Redacted)
+	}
+
+	// The replayed service listens on another port, which must not matter.
+	replay, err := New(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("failed to load cassette: %v", err)
+	}
+	replayed := useModel(t, "http://127.0.0.1:1", replay)
+	if got, want := replayed.ID, recorded.ID; got != want {
+		t.Errorf("got model ID %q, want %q", got, want)
+	}
+}
+
+// TestReplayMatching verifies how requests are matched against recorded interactions.
+func TestReplayMatching(t *testing.T) {
+	cassette := Cassette{Interactions: []Interaction{
+		{
+			Request:  Request{Method: http.MethodGet, Path: "/openai/load/m", Query: "ttl=600&ep=cuda"},
+			Response: Response{StatusCode: http.StatusOK, Body: "first"},
+		},
+		{
+			Request:  Request{Method: http.MethodGet, Path: "/openai/load/m", Query: "ttl=600&ep=cuda"},
+			Response: Response{StatusCode: http.StatusOK, Body: "second"},
+		},
+		{
+			Request:  Request{Method: http.MethodPost, Path: "/openai/download", Body: `{"token":"REDACTED","Model":{"Name":"m"}}`},
+			Response: Response{StatusCode: http.StatusOK, Body: "downloaded"},
+		},
+	}}
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := cassette.Save(path); err != nil {
+		t.Fatalf("failed to save cassette: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		url      string
+		body     string
+		wantBody []string
+	}{
+		{
+			name:     "query_order_and_sequence",
+			method:   http.MethodGet,
+			url:      "http://localhost:5273/openai/load/m?ep=cuda&ttl=600",
+			wantBody: []string{"first", "second"},
+		},
+		{
+			name:     "redacted_json_body",
+			method:   http.MethodPost,
+			url:      "http://localhost:5273/openai/download",
+			body:     `{"Model": {"Name": "m"}, "token": "other-secret"}`,
+			wantBody: []string{"downloaded"},
+		},
+		{
+			name:   "different_query",
+			method: http.MethodGet,
+			url:    "http://localhost:5273/openai/load/m?ttl=60",
+		},
+		{
+			name:   "different_method",
+			method: http.MethodPost,
+			url:    "http://localhost:5273/openai/load/m?ep=cuda&ttl=600",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec, err := New(path, ModeReplay)
+			if err != nil {
+				t.Fatalf("failed to load cassette: %v", err)
+			}
+			client := &http.Client{Transport: rec}
+
+			for _, want := range append(tc.wantBody, "") {
+				req, err := http.NewRequestWithContext(t.Context(), tc.method, tc.url, strings.NewReader(tc.body))
+				if err != nil {
+					t.Fatalf("failed to create request: %v", err)
+				}
+				resp, err := client.Do(req)
+				if want == "" {
+					// All matching interactions have been used.
+					if err == nil {
+						resp.Body.Close()
+						t.Fatalf("got nil error, want %v", ErrNoInteraction)
+					}
+					if !errors.Is(err, ErrNoInteraction) {
+						t.Errorf("got error %v, want %v", err, ErrNoInteraction)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("got error %v, want nil", err)
+				}
+				got, err := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				if err != nil {
+					t.Fatalf("failed to read body: %v", err)
+				}
+				if string(got) != want {
+					t.Errorf("got body %q, want %q", got, want)
+				}
+			}
+		})
+	}
+}
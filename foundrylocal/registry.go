@@ -0,0 +1,104 @@
+package foundrylocal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+)
+
+// toolNamePattern matches the function names accepted by OpenAI-compatible runtimes.
+var toolNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// ToolRegistry holds Go functions registered as tools. Use RegisterFunc to add
+// functions, and pass Tools and Handlers to a ChatRequest and RunTools.
+// A ToolRegistry is not safe for concurrent registration.
+//
+// Example:
+//
+//	type WeatherArgs struct {
+//		City string `json:"city" description:"Name of the city"`
+//		Unit string `json:"unit,omitempty" enum:"celsius,fahrenheit"`
+//	}
+//
+//	tools := foundrylocal.NewToolRegistry()
+//	err := foundrylocal.RegisterFunc(tools, "get_weather", "Returns the current weather in a city.",
+//		func(ctx context.Context, args WeatherArgs) (Weather, error) {
+//			return lookupWeather(ctx, args.City, args.Unit)
+//		})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	req := foundrylocal.ChatRequest{
+//		Model:    "qwen2.5-1.5b",
+//		Messages: []foundrylocal.ChatMessage{foundrylocal.UserMessage("What's the weather in Berlin?")},
+//		Tools:    tools.Tools(),
+//	}
+//	run, err := manager.RunTools(ctx, req, tools.Handlers())
+type ToolRegistry struct {
+	tools    []Tool
+	handlers map[string]ToolHandler
+}
+
+// NewToolRegistry returns an empty ToolRegistry.
+func NewToolRegistry() *ToolRegistry {
+	return &ToolRegistry{handlers: map[string]ToolHandler{}}
+}
+
+// RegisterFunc registers fn as a tool with the given name and description. The
+// parameters of the tool are derived from Args with SchemaFor. Before fn is called,
+// the arguments generated by the model are validated against the schema, and
+// violations are reported to the model as the tool result. The result of fn is
+// sent to the model as JSON, except for strings, which are sent as is.
+//
+// RegisterFunc returns an error if the name is invalid or already registered, or
+// if no schema can be derived from Args.
+func RegisterFunc[Args, Result any](r *ToolRegistry, name, description string, fn func(context.Context, Args) (Result, error)) error {
+	if !toolNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid tool name %q", name)
+	}
+	if _, ok := r.handlers[name]; ok {
+		return fmt.Errorf("tool %q is already registered", name)
+	}
+	schema, err := SchemaFor[Args]()
+	if err != nil {
+		return fmt.Errorf("tool %q: %w", name, err)
+	}
+
+	r.tools = append(r.tools, FunctionTool(name, description, schema))
+	r.handlers[name] = func(ctx context.Context, arguments json.RawMessage) (string, error) {
+		if err := schema.Validate(arguments); err != nil {
+			return "", fmt.Errorf("invalid arguments: %w", err)
+		}
+		var args Args
+		if err := json.Unmarshal(arguments, &args); err != nil {
+			return "", fmt.Errorf("invalid arguments: %w", err)
+		}
+		result, err := fn(ctx, args)
+		if err != nil {
+			return "", err
+		}
+		if s, ok := any(result).(string); ok {
+			return s, nil
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode result: %w", err)
+		}
+		return string(data), nil
+	}
+	return nil
+}
+
+// Tools returns the registered tools in the order they were registered.
+func (r *ToolRegistry) Tools() []Tool {
+	return slices.Clone(r.tools)
+}
+
+// Handlers returns the handlers of the registered tools by name.
+func (r *ToolRegistry) Handlers() map[string]ToolHandler {
+	return maps.Clone(r.handlers)
+}
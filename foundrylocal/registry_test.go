@@ -0,0 +1,208 @@
+package foundrylocal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type weatherArgs struct {
+	City string `json:"city" description:"Name of the city"`
+	Unit string `json:"unit,omitempty" enum:"celsius,fahrenheit"`
+}
+
+type weather struct {
+	Temperature int    `json:"temperature"`
+	Unit        string `json:"unit"`
+}
+
+func getWeather(ctx context.Context, args weatherArgs) (weather, error) {
+	if args.City == "Atlantis" {
+		return weather{}, errors.New("city not found")
+	}
+	unit := args.Unit
+	if unit == "" {
+		unit = "celsius"
+	}
+	return weather{Temperature: 21, Unit: unit}, nil
+}
+
+// TestRegisterFunc verifies that registered functions validate and decode their
+// arguments and encode their results.
+func TestRegisterFunc(t *testing.T) {
+	tools := NewToolRegistry()
+	if err := RegisterFunc(tools, "get_weather", "Returns the weather in a city.", getWeather); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	err := RegisterFunc(tools, "echo", "", func(ctx context.Context, args struct {
+		Text string `json:"text"`
+	}) (string, error) {
+		return args.Text, nil
+	})
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	if got, want := len(tools.Tools()), 2; got != want {
+		t.Fatalf("got %d tools, want %d", got, want)
+	}
+	tool := tools.Tools()[0]
+	if got, want := tool.Function.Name, "get_weather"; got != want {
+		t.Errorf("got name %q, want %q", got, want)
+	}
+	if got, want := tool.Function.Parameters.Required, []string{"city"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got required %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name       string
+		tool       string
+		arguments  string
+		wantResult string
+		wantErr    string
+	}{
+		{
+			name:       "struct_result",
+			tool:       "get_weather",
+			arguments:  `{"city":"Berlin","unit":"fahrenheit"}`,
+			wantResult: `{"temperature":21,"unit":"fahrenheit"}`,
+		},
+		{
+			name:       "string_result",
+			tool:       "echo",
+			arguments:  `{"text":"hello"}`,
+			wantResult: `hello`,
+		},
+		{
+			name:      "invalid_arguments",
+			tool:      "get_weather",
+			arguments: `{"town":"Berlin","unit":"kelvin"}`,
+			wantErr:   `invalid arguments: value does not match schema: $: missing required property "city"; $: unknown property "town"; $.unit: value kelvin is not one of [celsius fahrenheit]`,
+		},
+		{
+			name:      "function_error",
+			tool:      "get_weather",
+			arguments: `{"city":"Atlantis"}`,
+			wantErr:   "city not found",
+		},
+	}
+
+	handlers := tools.Handlers()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handlers[tc.tool](t.Context(), json.RawMessage(tc.arguments))
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("got error %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if got, want := result, tc.wantResult; got != want {
+				t.Errorf("got result %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+// TestRegisterFuncErrors verifies that invalid registrations are rejected.
+func TestRegisterFuncErrors(t *testing.T) {
+	tools := NewToolRegistry()
+	if err := RegisterFunc(tools, "get_weather", "", getWeather); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	tests := []struct {
+		name     string
+		register func() error
+		wantErr  string
+	}{
+		{
+			name:     "duplicate",
+			register: func() error { return RegisterFunc(tools, "get_weather", "", getWeather) },
+			wantErr:  `tool "get_weather" is already registered`,
+		},
+		{
+			name:     "invalid_name",
+			register: func() error { return RegisterFunc(tools, "get weather", "", getWeather) },
+			wantErr:  `invalid tool name "get weather"`,
+		},
+		{
+			name: "invalid_args",
+			register: func() error {
+				return RegisterFunc(tools, "count", "", func(ctx context.Context, n int) (int, error) { return n, nil })
+			},
+			wantErr: `tool "count": cannot derive schema from int: not a struct`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.register()
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("got error %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+	if got, want := len(tools.Tools()), 1; got != want {
+		t.Errorf("got %d tools, want %d", got, want)
+	}
+}
+
+// TestRegistryRunTools verifies that a registry plugs into RunTools and that
+// argument violations are reported to the model.
+func TestRegistryRunTools(t *testing.T) {
+	tools := NewToolRegistry()
+	if err := RegisterFunc(tools, "get_weather", "Returns the weather in a city.", getWeather); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	chat := &scriptedChat{responses: []ChatResponse{
+		toolCallResponse(ToolCall{ID: "call-1", Type: "function", Function: FunctionCall{Name: "get_weather", Arguments: `{"town":"Berlin"}`}}),
+		toolCallResponse(ToolCall{ID: "call-2", Type: "function", Function: FunctionCall{Name: "get_weather", Arguments: `{"city":"Berlin"}`}}),
+		answerResponse("It is 21 degrees."),
+	}}
+	mux := http.NewServeMux()
+	mux.Handle("/v1/chat/completions", chat)
+	mux.Handle("/", newHandler(toolCallingCatalog()))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	serviceURL, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to parse service URL: %v", err)
+	}
+
+	m := NewManager(WithHTTPClient(srv.Client()))
+	m.serviceURL = serviceURL
+
+	req := ChatRequest{
+		Model:    "model-2",
+		Messages: []ChatMessage{UserMessage("What's the weather in Berlin?")},
+		Tools:    tools.Tools(),
+	}
+	run, err := m.RunTools(t.Context(), req, tools.Handlers())
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	if got, want := run.Rounds, 2; got != want {
+		t.Errorf("got %d rounds, want %d", got, want)
+	}
+	if got, want := run.Messages[2].Content, `missing required property \"city\"`; !strings.Contains(got, want) {
+		t.Errorf("got first tool result %q, want it to contain %q", got, want)
+	}
+	if got, want := run.Messages[4].Content, `{"temperature":21,"unit":"celsius"}`; got != want {
+		t.Errorf("got second tool result %q, want %q", got, want)
+	}
+	if got, want := chat.requests[0].Tools[0].Function.Parameters.Properties["unit"].Enum, []any{"celsius", "fahrenheit"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got sent enum %v, want %v", got, want)
+	}
+}
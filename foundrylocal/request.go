@@ -0,0 +1,116 @@
+package foundrylocal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// operation describes a request to the Foundry Local service.
+// Requests are rebuilt from their operation for every attempt, so that they
+// always target the current service endpoint.
+type operation struct {
+	// name identifies the operation in logs, usually the name of the Manager method.
+	name string
+	// method is the HTTP method.
+	method string
+	// path contains the path elements relative to the service endpoint.
+	path []string
+	// query contains optional query parameters.
+	query url.Values
+	// body is an optional JSON request body.
+	body []byte
+	// header contains optional request headers.
+	header http.Header
+	// idempotent indicates whether the operation can safely be sent again.
+	idempotent bool
+	// class selects the timeout that applies to the operation.
+	class operationClass
+	// modelID is the ID of the model the operation acts on, if any.
+	modelID string
+}
+
+// do sends op to the Foundry Local service and returns the response.
+// The operation is bounded by the Manager's timeout for its class until the
+// response body is closed.
+func (m *Manager) do(ctx context.Context, op operation) (*http.Response, error) {
+	ctx, cancel := m.operationContext(ctx, op.class)
+	resp, err := m.sendWithRecovery(ctx, op)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// sendWithRecovery sends op, retrying idempotent GET requests according to the
+// Manager's RetryPolicy. If automatic recovery is enabled and the service cannot
+// be reached, it re-resolves the service endpoint, restarting the service if
+// necessary, and sends idempotent operations once more.
+func (m *Manager) sendWithRecovery(ctx context.Context, op operation) (*http.Response, error) {
+	resp, err := m.sendWithRetry(ctx, op)
+	if err == nil || !m.autoRecover || !op.idempotent || !isUnreachable(err) {
+		return resp, err
+	}
+
+	m.Logger.WarnContext(ctx, "Foundry service is unreachable, recovering",
+		"operation", op.name, "endpoint", m.serviceURL.String(), "error", err)
+	if recoverErr := m.recoverService(ctx); recoverErr != nil {
+		m.Logger.ErrorContext(ctx, "Foundry service recovery failed", "operation", op.name, "error", recoverErr)
+		return nil, err
+	}
+	m.Logger.InfoContext(ctx, "Foundry service recovered, retrying operation",
+		"operation", op.name, "endpoint", m.serviceURL.String())
+	return m.sendWithRetry(ctx, op)
+}
+
+// send builds the HTTP request for op against the current service endpoint and sends it.
+// Errors caused by an unreachable service wrap ErrServiceNotRunning, and non-success
+// responses are returned as *APIError.
+func (m *Manager) send(ctx context.Context, op operation) (*http.Response, error) {
+	endpoint := m.serviceURL.JoinPath(op.path...)
+	if op.query != nil {
+		endpoint.RawQuery = op.query.Encode()
+	}
+
+	var body io.Reader
+	if op.body != nil {
+		body = bytes.NewReader(op.body)
+	}
+	ctx = contextWithOperation(ctx, Operation{Name: op.name, ModelID: op.modelID})
+	req, err := http.NewRequestWithContext(ctx, op.method, endpoint.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range op.header {
+		req.Header[key] = values
+	}
+	if op.body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		if isUnreachable(err) {
+			return nil, fmt.Errorf("%w: %w", ErrServiceNotRunning, err)
+		}
+		return nil, err
+	}
+	if !ensureSuccessStatusCode(resp) {
+		defer resp.Body.Close()
+		return nil, newAPIError(op.name, resp)
+	}
+	return resp, nil
+}
+
+// recoverService discards the cached service endpoint and starts the service again,
+// which resolves the endpoint through the ServiceLauncher's status report.
+func (m *Manager) recoverService(ctx context.Context) error {
+	m.serviceURL = nil
+	m.runtimeVersion = ""
+	return m.StartService(ctx)
+}
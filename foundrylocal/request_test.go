@@ -0,0 +1,143 @@
+package foundrylocal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// TestAutoRecovery verifies that an unreachable service triggers endpoint
+// re-resolution and a single retry only when automatic recovery is enabled.
+func TestAutoRecovery(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []ManagerOption
+		wantStarts  int
+		wantRecover bool
+	}{
+		{
+			name:        "recovery_enabled",
+			opts:        []ManagerOption{WithAutoRecovery()},
+			wantStarts:  1,
+			wantRecover: true,
+		},
+		{
+			name:       "recovery_disabled",
+			wantStarts: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(newHandler(
+				mockCatalog(true),
+				mockLocalModels("model-2-npu:1")))
+			defer srv.Close()
+
+			// The service was restarted and no longer listens on the cached endpoint.
+			stale := httptest.NewServer(newHandler())
+			staleURL, err := url.Parse(stale.URL)
+			if err != nil {
+				t.Fatalf("failed to parse service URL: %v", err)
+			}
+			stale.Close()
+
+			// The crashed service is restarted by the launcher on a new port.
+			launcher := &fakeLauncher{
+				status:      "Model management service is not running!\n",
+				startStatus: "Model management service is running on " + srv.URL + "/openai/status\n",
+			}
+			m := NewManager(append(tc.opts, WithServiceLauncher(launcher), WithHTTPClient(srv.Client()))...)
+			m.serviceURL = staleURL
+
+			cached, err := m.ListCachedModels(t.Context())
+			if tc.wantRecover {
+				if err != nil {
+					t.Fatalf("failed to list cached models: %v", err)
+				}
+				if got, want := len(cached), 1; got != want {
+					t.Errorf("got %d cached models, want %d", got, want)
+				}
+				if got, want := m.serviceURL.String(), srv.URL; got != want {
+					t.Errorf("got endpoint %q, want %q", got, want)
+				}
+			} else if err == nil {
+				t.Fatalf("got nil error, want non-nil error")
+			}
+			if got, want := launcher.starts, tc.wantStarts; got != want {
+				t.Errorf("got %d launcher starts, want %d", got, want)
+			}
+		})
+	}
+}
+
+// countingTransport is an http.RoundTripper that records the requests it sends.
+type countingTransport struct {
+	requests atomic.Int32
+	agents   []string
+}
+
+func (rt *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	rt.requests.Add(1)
+	rt.agents = append(rt.agents, r.Header.Get("User-Agent"))
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// TestWithTransport verifies custom clients and transports are wrapped with the
+// SDK's User-Agent and survive StopService and StartService cycles.
+func TestWithTransport(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  func(rt http.RoundTripper) ManagerOption
+	}{
+		{
+			name: "with_transport",
+			opt:  WithTransport,
+		},
+		{
+			name: "with_http_client",
+			opt: func(rt http.RoundTripper) ManagerOption {
+				return WithHTTPClient(&http.Client{Transport: rt})
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(newHandler(
+				mockJSON("/openai/status", []byte(`{"modelDirPath": "/models"}`))))
+			defer srv.Close()
+
+			rt := &countingTransport{}
+			launcher := &fakeLauncher{
+				status:      "Model management service is not running!\n",
+				startStatus: "Model management service is running on " + srv.URL + "/openai/status\n",
+			}
+			m := NewManager(WithServiceLauncher(launcher), tc.opt(rt))
+
+			for range 2 {
+				if err := m.StartService(t.Context()); err != nil {
+					t.Fatalf("failed to start service: %v", err)
+				}
+				if _, err := m.GetCacheLocation(t.Context()); err != nil {
+					t.Fatalf("failed to get cache location: %v", err)
+				}
+				if err := m.StopService(t.Context()); err != nil {
+					t.Fatalf("failed to stop service: %v", err)
+				}
+			}
+
+			if got, want := rt.requests.Load(), int32(2); got != want {
+				t.Errorf("got %d requests through custom transport, want %d", got, want)
+			}
+			for _, agent := range rt.agents {
+				if !strings.HasPrefix(agent, "go-foundrylocal/") {
+					t.Errorf("got User-Agent %q, want go-foundrylocal/ prefix", agent)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package foundrylocal
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// RetryPolicy configures how the Manager retries idempotent GET requests to the
+// Foundry Local service, such as listing catalog, cached, or loaded models.
+// Requests that change state, such as downloads, are never retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values less than 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts.
+	MaxBackoff time.Duration
+	// Multiplier is the factor the delay grows by after each attempt.
+	// Values less than 1 are treated as 1.
+	Multiplier float64
+	// Jitter randomizes each delay by up to the given fraction in either direction,
+	// for example 0.2 for ±20%. Values are clamped to the range [0, 1].
+	Jitter float64
+	// RetryableStatusCodes lists the HTTP status codes that are retried.
+	RetryableStatusCodes []int
+	// RetryableError reports whether a transport error is retried.
+	// If nil, errors caused by an unreachable service or an interrupted
+	// response are retried.
+	RetryableError func(error) bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy with three attempts, exponential backoff
+// starting at 200 milliseconds with 20% jitter, and retries for status codes 429,
+// 502, 503, and 504.
+//
+// Example:
+//
+//	policy := foundrylocal.DefaultRetryPolicy()
+//	policy.MaxAttempts = 5
+//	manager := foundrylocal.NewManager(foundrylocal.WithRetryPolicy(policy))
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// retryable reports whether err is worth another attempt under the policy.
+func (p RetryPolicy) retryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return slices.Contains(p.RetryableStatusCodes, apiErr.StatusCode)
+	}
+	if p.RetryableError != nil {
+		return p.RetryableError(err)
+	}
+	return isUnreachable(err) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// backoff returns the delay before the given retry, starting at 1.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	delay := float64(p.InitialBackoff)
+	for range retry - 1 {
+		delay *= max(p.Multiplier, 1)
+	}
+	if p.MaxBackoff > 0 {
+		delay = min(delay, float64(p.MaxBackoff))
+	}
+	jitter := min(max(p.Jitter, 0), 1)
+	delay *= 1 + jitter*(2*rand.Float64()-1)
+	return time.Duration(delay)
+}
+
+// sendWithRetry sends op and retries it according to the Manager's RetryPolicy if op
+// is an idempotent GET request. Each retry is logged with the Manager's Logger.
+// Waiting between attempts stops as soon as ctx is done.
+func (m *Manager) sendWithRetry(ctx context.Context, op operation) (*http.Response, error) {
+	policy := m.retryPolicy
+	if !op.idempotent || op.method != http.MethodGet {
+		policy.MaxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := m.send(ctx, op)
+		if err == nil || attempt >= policy.MaxAttempts || !policy.retryable(err) || ctx.Err() != nil {
+			return resp, err
+		}
+
+		delay := policy.backoff(attempt)
+		m.Logger.WarnContext(ctx, "Foundry service request failed, retrying",
+			"operation", op.name, "attempt", attempt, "maxAttempts", policy.MaxAttempts, "backoff", delay, "error", err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, errors.Join(err, ctx.Err())
+		}
+	}
+}
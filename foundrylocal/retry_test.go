@@ -0,0 +1,151 @@
+package foundrylocal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRetryPolicy verifies idempotent GET requests are retried for retryable
+// status codes until they succeed or the attempts are exhausted.
+func TestRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		failures     int
+		failStatus   int
+		call         func(m *Manager) error
+		wantRequests int32
+		wantErr      bool
+	}{
+		{
+			name:       "succeeds_after_retries",
+			path:       "/openai/models",
+			failures:   2,
+			failStatus: http.StatusServiceUnavailable,
+			call: func(m *Manager) error {
+				_, err := m.ListCachedModels(t.Context())
+				return err
+			},
+			wantRequests: 3,
+		},
+		{
+			name:       "attempts_exhausted",
+			path:       "/openai/models",
+			failures:   3,
+			failStatus: http.StatusServiceUnavailable,
+			call: func(m *Manager) error {
+				_, err := m.ListCachedModels(t.Context())
+				return err
+			},
+			wantRequests: 3,
+			wantErr:      true,
+		},
+		{
+			name:       "status_not_retryable",
+			path:       "/openai/models",
+			failures:   1,
+			failStatus: http.StatusInternalServerError,
+			call: func(m *Manager) error {
+				_, err := m.ListCachedModels(t.Context())
+				return err
+			},
+			wantRequests: 1,
+			wantErr:      true,
+		},
+		{
+			name:       "post_not_retried",
+			path:       "/openai/download",
+			failures:   1,
+			failStatus: http.StatusServiceUnavailable,
+			call: func(m *Manager) error {
+				_, err := m.DownloadModel(t.Context(), "model-2", nil, WithForceDownload())
+				return err
+			},
+			wantRequests: 1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests atomic.Int32
+			routes := newHandler(
+				mockCatalog(true),
+				mockLocalModels("model-2-npu:2"),
+				mockJSON("/openai/download", []byte(`{"success": true}`)))
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == tc.path {
+					if n := requests.Add(1); n <= int32(tc.failures) {
+						http.Error(w, "busy", tc.failStatus)
+						return
+					}
+				}
+				routes.ServeHTTP(w, r)
+			}))
+			defer srv.Close()
+			serviceURL, err := url.Parse(srv.URL)
+			if err != nil {
+				t.Fatalf("failed to parse service URL: %v", err)
+			}
+
+			policy := DefaultRetryPolicy()
+			policy.InitialBackoff = time.Millisecond
+			m := NewManager(WithRetryPolicy(policy), WithHTTPClient(srv.Client()))
+			m.serviceURL = serviceURL
+
+			err = tc.call(m)
+			if got, want := err != nil, tc.wantErr; got != want {
+				t.Fatalf("got error %v, want error %t", err, want)
+			}
+			if got, want := requests.Load(), tc.wantRequests; got != want {
+				t.Errorf("got %d requests, want %d", got, want)
+			}
+		})
+	}
+}
+
+// TestRetryPolicyContextCanceled verifies waiting for the next attempt stops
+// when the context is canceled.
+func TestRetryPolicyContextCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "busy", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	serviceURL, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to parse service URL: %v", err)
+	}
+
+	policy := DefaultRetryPolicy()
+	policy.MaxAttempts = 10
+	policy.InitialBackoff = time.Hour
+	m := NewManager(WithRetryPolicy(policy), WithHTTPClient(srv.Client()))
+	m.serviceURL = serviceURL
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := m.ListLoadedModels(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+// TestRetryPolicyBackoff verifies the delay grows exponentially up to MaxBackoff.
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+	}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second}
+	for i, w := range want {
+		if got := policy.backoff(i + 1); got != w {
+			t.Errorf("retry %d: got backoff %s, want %s", i+1, got, w)
+		}
+	}
+}
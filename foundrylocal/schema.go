@@ -0,0 +1,349 @@
+package foundrylocal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONSchema is a subset of JSON Schema that describes the parameters of a Tool.
+// Only the keywords that OpenAI-compatible runtimes understand are supported.
+//
+// Example:
+//
+//	schema := &foundrylocal.JSONSchema{
+//		Type: "object",
+//		Properties: map[string]*foundrylocal.JSONSchema{
+//			"city": {Type: "string", Description: "Name of the city"},
+//			"unit": {Type: "string", Enum: []any{"celsius", "fahrenheit"}},
+//		},
+//		Required: []string{"city"},
+//	}
+type JSONSchema struct {
+	// Type is the JSON type, such as "object", "array", "string", "number",
+	// "integer", or "boolean". If empty, any value is allowed.
+	Type string `json:"type,omitempty"`
+	// Description explains the value to the model.
+	Description string `json:"description,omitempty"`
+	// Format is a hint for the format of strings, such as "date-time".
+	Format string `json:"format,omitempty"`
+	// Properties describes the properties of an object.
+	Properties map[string]*JSONSchema `json:"properties,omitempty"`
+	// Required lists the properties an object must have.
+	Required []string `json:"required,omitempty"`
+	// Items describes the elements of an array.
+	Items *JSONSchema `json:"items,omitempty"`
+	// Enum lists the allowed values.
+	Enum []any `json:"enum,omitempty"`
+	// AdditionalProperties controls whether an object may have properties that
+	// are not listed in Properties. If nil, additional properties are allowed.
+	AdditionalProperties *bool `json:"additionalProperties,omitempty"`
+}
+
+// SchemaFor derives a JSONSchema from the Go type T, which must be a struct or a
+// pointer to a struct. Properties are named after their json tags, and fields
+// without the omitempty or omitzero option are required. The description tag sets
+// the description of a property, and the enum tag lists its allowed values,
+// separated by commas. Unexported fields and fields tagged with `json:"-"` are
+// ignored, and the fields of embedded structs are promoted. Objects derived from
+// structs do not allow additional properties.
+//
+// Example:
+//
+//	type WeatherArgs struct {
+//		City string `json:"city" description:"Name of the city"`
+//		Unit string `json:"unit,omitempty" enum:"celsius,fahrenheit"`
+//	}
+//
+//	schema, err := foundrylocal.SchemaFor[WeatherArgs]()
+func SchemaFor[T any]() (*JSONSchema, error) {
+	t := reflect.TypeFor[T]()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot derive schema from %s: not a struct", t)
+	}
+	return schemaOf(t, nil)
+}
+
+var (
+	timeType    = reflect.TypeFor[time.Time]()
+	rawJSONType = reflect.TypeFor[json.RawMessage]()
+)
+
+// schemaOf derives the schema of t. seen contains the struct types being derived
+// to detect recursive types, which cannot be described without references.
+func schemaOf(t reflect.Type, seen []reflect.Type) (*JSONSchema, error) {
+	switch {
+	case t == timeType:
+		return &JSONSchema{Type: "string", Format: "date-time"}, nil
+	case t == rawJSONType:
+		return &JSONSchema{}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaOf(t.Elem(), seen)
+	case reflect.String:
+		return &JSONSchema{Type: "string"}, nil
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}, nil
+	case reflect.Interface:
+		return &JSONSchema{}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json encodes byte slices as base64 strings.
+			return &JSONSchema{Type: "string"}, nil
+		}
+		items, err := schemaOf(t.Elem(), seen)
+		if err != nil {
+			return nil, err
+		}
+		return &JSONSchema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("cannot derive schema from %s: map keys must be strings", t)
+		}
+		return &JSONSchema{Type: "object"}, nil
+	case reflect.Struct:
+		if slices.Contains(seen, t) {
+			return nil, fmt.Errorf("cannot derive schema from %s: recursive type", t)
+		}
+		schema := &JSONSchema{
+			Type:                 "object",
+			Properties:           map[string]*JSONSchema{},
+			AdditionalProperties: Ptr(false),
+		}
+		if err := addFields(schema, t, append(seen, t)); err != nil {
+			return nil, err
+		}
+		return schema, nil
+	default:
+		return nil, fmt.Errorf("cannot derive schema from %s: unsupported kind %s", t, t.Kind())
+	}
+}
+
+// addFields adds the properties of the fields of the struct type t to schema.
+func addFields(schema *JSONSchema, t reflect.Type, seen []reflect.Type) error {
+	for field := range fields(t) {
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			if err := addFields(schema, fieldType, seen); err != nil {
+				return err
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property, err := schemaOf(field.Type, seen)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		property.Description = field.Tag.Get("description")
+		if enum, ok := field.Tag.Lookup("enum"); ok {
+			if property.Enum, err = parseEnum(enum, property.Type); err != nil {
+				return fmt.Errorf("field %s: %w", field.Name, err)
+			}
+		}
+
+		schema.Properties[name] = property
+		if options := strings.Split(opts, ","); !slices.Contains(options, "omitempty") && !slices.Contains(options, "omitzero") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return nil
+}
+
+// fields yields the exported and embedded fields of the struct type t.
+func fields(t reflect.Type) func(yield func(reflect.StructField) bool) {
+	return func(yield func(reflect.StructField) bool) {
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if !field.IsExported() && !field.Anonymous {
+				continue
+			}
+			if !yield(field) {
+				return
+			}
+		}
+	}
+}
+
+// parseEnum parses the comma-separated values of an enum tag for a property of
+// the given JSON type.
+func parseEnum(tag, typ string) ([]any, error) {
+	var values []any
+	for value := range strings.SplitSeq(tag, ",") {
+		value = strings.TrimSpace(value)
+		switch typ {
+		case "string":
+			values = append(values, value)
+		case "integer":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid enum value %q: %w", value, err)
+			}
+			values = append(values, n)
+		case "number":
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid enum value %q: %w", value, err)
+			}
+			values = append(values, f)
+		default:
+			return nil, fmt.Errorf("enum is not supported for type %q", typ)
+		}
+	}
+	return values, nil
+}
+
+// Validate checks that data is a JSON document that matches the schema. It checks
+// types, required properties, enums, and additional properties. Properties that are
+// not required may be null. The error wraps ErrSchemaViolation and lists all
+// violations found.
+func (s *JSONSchema) Validate(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("%w: invalid JSON: %w", ErrSchemaViolation, err)
+	}
+	if decoder.More() {
+		return fmt.Errorf("%w: invalid JSON: unexpected data after top-level value", ErrSchemaViolation)
+	}
+
+	var problems []string
+	s.validate(value, "$", &problems)
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrSchemaViolation, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// validate appends the violations of value at path to problems.
+func (s *JSONSchema) validate(value any, path string, problems *[]string) {
+	if s == nil {
+		return
+	}
+	fail := func(format string, args ...any) {
+		*problems = append(*problems, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	switch s.Type {
+	case "":
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			fail("expected object, got %s", jsonType(value))
+			return
+		}
+		for _, name := range s.Required {
+			if _, ok := object[name]; !ok {
+				fail("missing required property %q", name)
+			}
+		}
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			property, ok := s.Properties[name]
+			switch {
+			case !ok && s.AdditionalProperties != nil && !*s.AdditionalProperties:
+				fail("unknown property %q", name)
+			case !ok:
+			case object[name] == nil && !slices.Contains(s.Required, name):
+			default:
+				property.validate(object[name], path+"."+name, problems)
+			}
+		}
+	case "array":
+		array, ok := value.([]any)
+		if !ok {
+			fail("expected array, got %s", jsonType(value))
+			return
+		}
+		for i, item := range array {
+			s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i), problems)
+		}
+	case "string", "boolean", "number":
+		if got := jsonType(value); got != s.Type && !(s.Type == "number" && got == "integer") {
+			fail("expected %s, got %s", s.Type, got)
+			return
+		}
+	case "integer":
+		if got := jsonType(value); got != "integer" {
+			fail("expected integer, got %s", got)
+			return
+		}
+	}
+
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(allowed any) bool { return equalJSON(allowed, value) }) {
+		fail("value %v is not one of %v", value, s.Enum)
+	}
+}
+
+// jsonType returns the JSON type of a value decoded with UseNumber.
+func jsonType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// equalJSON reports whether an enum value equals a decoded JSON value.
+func equalJSON(allowed, value any) bool {
+	if n, ok := value.(json.Number); ok {
+		f, err := n.Float64()
+		if err != nil {
+			return false
+		}
+		switch a := allowed.(type) {
+		case int64:
+			return float64(a) == f
+		case int:
+			return float64(a) == f
+		case float64:
+			return a == f
+		}
+		return false
+	}
+	return allowed == value
+}
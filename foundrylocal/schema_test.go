@@ -0,0 +1,186 @@
+package foundrylocal
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type schemaAddress struct {
+	Street string `json:"street"`
+	Zip    string `json:"zip,omitempty"`
+}
+
+type schemaBase struct {
+	ID int `json:"id" description:"Identifier"`
+}
+
+type schemaArgs struct {
+	schemaBase
+	Name     string            `json:"name" description:"Name of the person"`
+	Unit     string            `json:"unit,omitempty" enum:"celsius,fahrenheit"`
+	Level    int               `json:"level" enum:"1,2,3"`
+	Score    *float64          `json:"score,omitempty"`
+	Tags     []string          `json:"tags,omitzero"`
+	Address  schemaAddress     `json:"address"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Since    time.Time         `json:"since,omitzero"`
+	Extra    any               `json:"extra,omitempty"`
+	Ignored  string            `json:"-"`
+	Untagged bool
+	internal string
+}
+
+type schemaRecursive struct {
+	Children []schemaRecursive `json:"children"`
+}
+
+// TestSchemaFor verifies that schemas are derived from struct fields and tags.
+func TestSchemaFor(t *testing.T) {
+	schema, err := SchemaFor[*schemaArgs]()
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	got, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("failed to marshal schema: %v", err)
+	}
+	want := `{"type":"object","properties":{` +
+		`"Untagged":{"type":"boolean"},` +
+		`"address":{"type":"object","properties":{"street":{"type":"string"},"zip":{"type":"string"}},"required":["street"],"additionalProperties":false},` +
+		`"extra":{},` +
+		`"id":{"type":"integer","description":"Identifier"},` +
+		`"labels":{"type":"object"},` +
+		`"level":{"type":"integer","enum":[1,2,3]},` +
+		`"name":{"type":"string","description":"Name of the person"},` +
+		`"score":{"type":"number"},` +
+		`"since":{"type":"string","format":"date-time"},` +
+		`"tags":{"type":"array","items":{"type":"string"}},` +
+		`"unit":{"type":"string","enum":["celsius","fahrenheit"]}},` +
+		`"required":["id","name","level","address","Untagged"],"additionalProperties":false}`
+	if string(got) != want {
+		t.Errorf("got schema\n%s\nwant\n%s", got, want)
+	}
+}
+
+// TestSchemaForErrors verifies that types without a JSON Schema are rejected.
+func TestSchemaForErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		derive  func() (*JSONSchema, error)
+		wantErr string
+	}{
+		{
+			name:    "not_a_struct",
+			derive:  SchemaFor[[]string],
+			wantErr: "not a struct",
+		},
+		{
+			name:    "recursive",
+			derive:  SchemaFor[schemaRecursive],
+			wantErr: "recursive type",
+		},
+		{
+			name: "unsupported_kind",
+			derive: SchemaFor[struct {
+				Callback func() `json:"callback"`
+			}],
+			wantErr: "unsupported kind func",
+		},
+		{
+			name: "invalid_enum",
+			derive: SchemaFor[struct {
+				Level int `json:"level" enum:"low,high"`
+			}],
+			wantErr: `invalid enum value "low"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.derive()
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("got error %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// TestValidate verifies that JSON values are checked against a schema.
+func TestValidate(t *testing.T) {
+	schema, err := SchemaFor[schemaArgs]()
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			data: `{"id":1,"name":"Ada","level":2,"address":{"street":"Main St"},"Untagged":true,"unit":"celsius","score":1,"tags":["a"],"extra":[1]}`,
+		},
+		{
+			name: "null_optional",
+			data: `{"id":1,"name":"Ada","level":2,"address":{"street":"Main St"},"Untagged":true,"unit":null}`,
+		},
+		{
+			name:    "missing_required",
+			data:    `{"id":1,"level":2,"address":{},"Untagged":true}`,
+			wantErr: `$: missing required property "name"; $.address: missing required property "street"`,
+		},
+		{
+			name:    "wrong_types",
+			data:    `{"id":1.5,"name":3,"level":2,"address":{"street":"Main St"},"Untagged":"yes","tags":[1]}`,
+			wantErr: `$.Untagged: expected boolean, got string; $.id: expected integer, got number; $.name: expected string, got integer; $.tags[0]: expected string, got integer`,
+		},
+		{
+			name:    "enum",
+			data:    `{"id":1,"name":"Ada","level":4,"address":{"street":"Main St"},"Untagged":true,"unit":"kelvin"}`,
+			wantErr: `$.level: value 4 is not one of [1 2 3]; $.unit: value kelvin is not one of [celsius fahrenheit]`,
+		},
+		{
+			name:    "unknown_property",
+			data:    `{"id":1,"name":"Ada","level":2,"address":{"street":"Main St"},"Untagged":true,"city":"Berlin"}`,
+			wantErr: `$: unknown property "city"`,
+		},
+		{
+			name:    "null_required",
+			data:    `{"id":1,"name":null,"level":2,"address":{"street":"Main St"},"Untagged":true}`,
+			wantErr: `$.name: expected string, got null`,
+		},
+		{
+			name:    "invalid_json",
+			data:    `{"id":`,
+			wantErr: "invalid JSON",
+		},
+		{
+			name:    "not_an_object",
+			data:    `[]`,
+			wantErr: "$: expected object, got array",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := schema.Validate([]byte(tc.data))
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("got error %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrSchemaViolation) {
+				t.Fatalf("got error %v, want %v", err, ErrSchemaViolation)
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("got error %q, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
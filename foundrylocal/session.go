@@ -0,0 +1,316 @@
+package foundrylocal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"unicode/utf8"
+)
+
+// DefaultContextWindow is the context window in tokens a Session assumes if none is
+// set with WithContextWindow. The catalog does not report the context window of a
+// model, so it should be set for models with a larger window.
+const DefaultContextWindow = 8192
+
+// messageOverhead is the estimated number of tokens a message takes in addition to
+// its content, for example for role markers.
+const messageOverhead = 4
+
+// sessionVersion is the version of the JSON format of a Session.
+const sessionVersion = 1
+
+// SessionOption configures a Session.
+type SessionOption func(*sessionConfig)
+
+type sessionConfig struct {
+	systemPrompt  string
+	contextWindow int
+	maxTokens     int
+}
+
+// WithSystemPrompt sets the system prompt that is sent at the start of every request
+// of a Session.
+//
+// Example:
+//
+//	session, err := manager.NewSession(ctx, "phi-4-mini",
+//		foundrylocal.WithSystemPrompt("You are a helpful assistant."))
+func WithSystemPrompt(prompt string) SessionOption {
+	return func(cfg *sessionConfig) {
+		cfg.systemPrompt = prompt
+	}
+}
+
+// WithContextWindow sets the context window of the model in tokens. A Session trims
+// the oldest turns of its history so that the request and the answer fit into it.
+// The default is DefaultContextWindow.
+//
+// Example:
+//
+//	session, err := manager.NewSession(ctx, "phi-4-mini", foundrylocal.WithContextWindow(128_000))
+func WithContextWindow(tokens int) SessionOption {
+	return func(cfg *sessionConfig) {
+		cfg.contextWindow = tokens
+	}
+}
+
+// WithReplyTokens sets how many tokens of the context window a Session reserves for
+// each answer, which is sent as max_tokens. By default, a quarter of the context
+// window is reserved, but no more than the model's MaxOutputTokens. NewSession
+// returns an error if the reply tokens set with WithReplyTokens do not fit into the
+// context window.
+//
+// Example:
+//
+//	session, err := manager.NewSession(ctx, "phi-4-mini", foundrylocal.WithReplyTokens(256))
+func WithReplyTokens(tokens int) SessionOption {
+	return func(cfg *sessionConfig) {
+		cfg.maxTokens = tokens
+	}
+}
+
+// Session is a multi-turn conversation with a model. It keeps the message history
+// and a system prompt, and sends as many of the most recent turns as fit into the
+// model's context window. A turn is a user message together with the messages that
+// answer it. Token counts are estimated as one token per four characters.
+//
+// A Session is safe for concurrent use, but Send calls are serialized. Use Fork to
+// branch a conversation, and json.Marshal and Manager.RestoreSession to persist it.
+//
+// Example:
+//
+//	session, err := manager.NewSession(ctx, "phi-4-mini",
+//		foundrylocal.WithSystemPrompt("You are a helpful assistant."))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	reply, err := session.Send(ctx, "What is the capital of France?")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(reply.Content)
+//	reply, err = session.Send(ctx, "And of Italy?")
+type Session struct {
+	manager     *Manager
+	model       ModelInfo
+	config      sessionConfig
+	replyTokens int
+
+	mu      sync.Mutex
+	history []ChatMessage
+}
+
+// NewSession returns a Session with the model resolved by GetModelInfo.
+// It returns an error if the reply tokens do not fit into the context window.
+func (m *Manager) NewSession(ctx context.Context, aliasOrModelID string, opts ...SessionOption) (*Session, error) {
+	modelInfo, err := m.GetModelInfo(ctx, aliasOrModelID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	config := sessionConfig{
+		contextWindow: DefaultContextWindow,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return m.newSession(modelInfo, config, nil)
+}
+
+// newSession returns a Session after validating its configuration. If no reply
+// tokens were set, they are derived from the context window and the model's limit.
+func (m *Manager) newSession(modelInfo ModelInfo, config sessionConfig, history []ChatMessage) (*Session, error) {
+	if config.contextWindow <= 0 {
+		return nil, fmt.Errorf("invalid context window of %d tokens", config.contextWindow)
+	}
+	replyTokens := config.maxTokens
+	switch {
+	case replyTokens == 0 && modelInfo.MaxOutputTokens > 0:
+		replyTokens = min(modelInfo.MaxOutputTokens, config.contextWindow/4)
+	case replyTokens == 0:
+		replyTokens = config.contextWindow / 4
+	case replyTokens < 0 || replyTokens >= config.contextWindow:
+		return nil, fmt.Errorf("%d reply tokens do not fit into the context window of %d tokens", replyTokens, config.contextWindow)
+	}
+	return &Session{
+		manager:     m,
+		model:       modelInfo,
+		config:      config,
+		replyTokens: replyTokens,
+		history:     history,
+	}, nil
+}
+
+// Model returns the model of the Session.
+func (s *Session) Model() ModelInfo {
+	return s.model
+}
+
+// SystemPrompt returns the system prompt of the Session.
+func (s *Session) SystemPrompt() string {
+	return s.config.systemPrompt
+}
+
+// History returns a copy of the messages of the conversation, without the system
+// prompt. It includes turns that no longer fit into the context window.
+func (s *Session) History() []ChatMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.history)
+}
+
+// Reset clears the history of the Session. The system prompt is kept.
+func (s *Session) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history = nil
+}
+
+// Fork returns a new Session with a copy of the history, so that both conversations
+// can continue independently.
+func (s *Session) Fork() *Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &Session{
+		manager:     s.manager,
+		model:       s.model,
+		config:      s.config,
+		replyTokens: s.replyTokens,
+		history:     slices.Clone(s.history),
+	}
+}
+
+// Send adds a user message with content to the conversation, sends the system
+// prompt and the most recent turns that fit into the context window to the model,
+// and adds the answer to the history. If the request fails, the history is not
+// changed. Send returns ErrContextWindowExceeded if the system prompt and the
+// message alone do not fit into the context window.
+func (s *Session) Send(ctx context.Context, content string) (ChatMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	history := append(slices.Clip(s.history), UserMessage(content))
+	messages, err := s.window(history)
+	if err != nil {
+		return ChatMessage{}, err
+	}
+
+	req := ChatRequest{Model: s.model.ID, Messages: messages}
+	if s.replyTokens > 0 {
+		req.MaxTokens = Ptr(s.replyTokens)
+	}
+	resp, err := s.manager.Chat(ctx, req)
+	if err != nil {
+		return ChatMessage{}, err
+	}
+
+	reply := resp.Message()
+	s.history = append(history, reply)
+	return reply, nil
+}
+
+// window returns the system prompt and the most recent turns of history that fit
+// into the context window together with the reply tokens.
+func (s *Session) window(history []ChatMessage) ([]ChatMessage, error) {
+	var system []ChatMessage
+	if s.config.systemPrompt != "" {
+		system = append(system, SystemMessage(s.config.systemPrompt))
+	}
+
+	budget := s.config.contextWindow - s.replyTokens - estimateTokens(system...)
+	start := len(history)
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Role != RoleUser {
+			continue
+		}
+		tokens := estimateTokens(history[i:start]...)
+		if tokens > budget {
+			break
+		}
+		budget -= tokens
+		start = i
+	}
+	if start == len(history) {
+		return nil, fmt.Errorf("%w: %d tokens available", ErrContextWindowExceeded, max(budget, 0))
+	}
+	return append(system, history[start:]...), nil
+}
+
+// estimateTokens estimates the number of tokens of messages as one token per four
+// characters plus an overhead per message.
+func estimateTokens(messages ...ChatMessage) int {
+	var tokens int
+	for _, message := range messages {
+		chars := utf8.RuneCountInString(message.Content)
+		for _, call := range message.ToolCalls {
+			chars += utf8.RuneCountInString(call.Function.Name) + utf8.RuneCountInString(call.Function.Arguments)
+		}
+		tokens += (chars+3)/4 + messageOverhead
+	}
+	return tokens
+}
+
+// sessionState is the JSON representation of a Session.
+type sessionState struct {
+	Version       int    `json:"version"`
+	Model         string `json:"model"`
+	SystemPrompt  string `json:"systemPrompt,omitzero"`
+	ContextWindow int    `json:"contextWindow"`
+	// ReplyTokens is only set if the Session was created with WithReplyTokens, so
+	// that the default is derived again when the Session is restored.
+	ReplyTokens int           `json:"replyTokens,omitzero"`
+	Messages    []ChatMessage `json:"messages"`
+}
+
+// MarshalJSON encodes the model ID, the configuration, and the history of the
+// Session. Use Manager.RestoreSession to restore it.
+func (s *Session) MarshalJSON() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return json.Marshal(sessionState{
+		Version:       sessionVersion,
+		Model:         s.model.ID,
+		SystemPrompt:  s.config.systemPrompt,
+		ContextWindow: s.config.contextWindow,
+		ReplyTokens:   s.config.maxTokens,
+		Messages:      s.history,
+	})
+}
+
+// RestoreSession restores a Session from JSON created by marshaling a Session.
+// The model is resolved again with GetModelInfo. Options override the restored
+// configuration.
+//
+// Example:
+//
+//	data, err := json.Marshal(session)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	// ...
+//	session, err = manager.RestoreSession(ctx, data)
+func (m *Manager) RestoreSession(ctx context.Context, data []byte, opts ...SessionOption) (*Session, error) {
+	var state sessionState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode session: %w", err)
+	}
+	if state.Version != sessionVersion {
+		return nil, fmt.Errorf("unsupported session version %d", state.Version)
+	}
+
+	modelInfo, err := m.GetModelInfo(ctx, state.Model, nil)
+	if err != nil {
+		return nil, err
+	}
+	config := sessionConfig{
+		systemPrompt:  state.SystemPrompt,
+		contextWindow: state.ContextWindow,
+		maxTokens:     state.ReplyTokens,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return m.newSession(modelInfo, config, state.Messages)
+}
@@ -0,0 +1,302 @@
+package foundrylocal
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newSessionManager returns a Manager for a server that serves the catalog and
+// answers chat completion requests with chat.
+func newSessionManager(t *testing.T, chat http.Handler) *Manager {
+	t.Helper()
+	return newSessionManagerWithCatalog(t, chat, mockCatalog(true))
+}
+
+// newSessionManagerWithCatalog is like newSessionManager, but serves catalog.
+func newSessionManagerWithCatalog(t *testing.T, chat http.Handler, catalog route) *Manager {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.Handle("/v1/chat/completions", chat)
+	mux.Handle("/", newHandler(catalog))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	serviceURL, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to parse service URL: %v", err)
+	}
+
+	m := NewManager(WithHTTPClient(srv.Client()))
+	m.serviceURL = serviceURL
+	return m
+}
+
+// TestSession verifies that a Session sends its system prompt and history and
+// records the answers.
+func TestSession(t *testing.T) {
+	chat := &scriptedChat{responses: []ChatResponse{answerResponse("Paris."), answerResponse("Rome.")}}
+	m := newSessionManager(t, chat)
+
+	session, err := m.NewSession(t.Context(), "model-2", WithSystemPrompt("Be brief."))
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if got, want := session.Model().ID, "model-2-npu:2"; got != want {
+		t.Errorf("got model %q, want %q", got, want)
+	}
+	for _, content := range []string{"Capital of France?", "And of Italy?"} {
+		if _, err := session.Send(t.Context(), content); err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+	}
+
+	wantHistory := []ChatMessage{
+		UserMessage("Capital of France?"),
+		AssistantMessage("Paris."),
+		UserMessage("And of Italy?"),
+		AssistantMessage("Rome."),
+	}
+	if got := session.History(); !reflect.DeepEqual(got, wantHistory) {
+		t.Errorf("got history %+v, want %+v", got, wantHistory)
+	}
+	last := chat.requests[1]
+	if got, want := last.Messages, append([]ChatMessage{SystemMessage("Be brief.")}, wantHistory[:3]...); !reflect.DeepEqual(got, want) {
+		t.Errorf("got messages %+v, want %+v", got, want)
+	}
+	if got, want := *last.MaxTokens, 1024; got != want {
+		t.Errorf("got max_tokens %d, want %d", got, want)
+	}
+	if got, want := last.Model, "model-2-npu:2"; got != want {
+		t.Errorf("got model %q, want %q", got, want)
+	}
+}
+
+// TestSessionTrimming verifies that the oldest turns are not sent once the
+// conversation exceeds the context window.
+func TestSessionTrimming(t *testing.T) {
+	// Each turn takes 19 tokens: a user message of 40 characters (10 tokens) and an
+	// answer of 4 characters (1 token), plus 4 tokens of overhead per message.
+	question := strings.Repeat("a", 40)
+
+	tests := []struct {
+		name          string
+		contextWindow int
+		replyTokens   int
+		systemPrompt  string
+		turns         int
+		wantSent      int
+		wantErr       error
+	}{
+		{name: "all_fit", contextWindow: 200, replyTokens: 100, turns: 3, wantSent: 5},
+		{name: "oldest_trimmed", contextWindow: 100, replyTokens: 50, turns: 4, wantSent: 3},
+		{name: "system_prompt_counts", contextWindow: 100, replyTokens: 50, systemPrompt: strings.Repeat("s", 60), turns: 4, wantSent: 2},
+		{name: "message_too_long", contextWindow: 100, replyTokens: 90, turns: 1, wantErr: ErrContextWindowExceeded},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			chat := &scriptedChat{responses: []ChatResponse{answerResponse("Okay")}}
+			m := newSessionManager(t, chat)
+			session, err := m.NewSession(t.Context(), "model-2",
+				WithContextWindow(tc.contextWindow), WithReplyTokens(tc.replyTokens), WithSystemPrompt(tc.systemPrompt))
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+
+			for range tc.turns {
+				_, err = session.Send(t.Context(), question)
+			}
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tc.wantErr)
+				}
+				if got := len(session.History()); got != 0 {
+					t.Errorf("got %d messages in history, want 0", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+
+			sent := chat.requests[len(chat.requests)-1].Messages
+			if got, want := len(sent), tc.wantSent; got != want {
+				t.Errorf("got %d messages sent, want %d", got, want)
+			}
+			if tc.systemPrompt != "" && sent[0].Role != RoleSystem {
+				t.Errorf("got first role %q, want %q", sent[0].Role, RoleSystem)
+			}
+			if got, want := len(session.History()), 2*tc.turns; got != want {
+				t.Errorf("got %d messages in history, want %d", got, want)
+			}
+		})
+	}
+}
+
+// TestSessionSendError verifies that a failed request leaves the history unchanged.
+func TestSessionSendError(t *testing.T) {
+	var (
+		body   map[string]any
+		header http.Header
+	)
+	m := newSessionManager(t, mockChat(t, http.StatusInternalServerError, `{"error":"boom"}`, &body, &header))
+	session, err := m.NewSession(t.Context(), "model-2")
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	var apiErr *APIError
+	if _, err := session.Send(t.Context(), "Hello"); !errors.As(err, &apiErr) {
+		t.Fatalf("got error %v, want APIError", err)
+	}
+	if got := len(session.History()); got != 0 {
+		t.Errorf("got %d messages in history, want 0", got)
+	}
+}
+
+// TestSessionFork verifies that forked sessions continue independently.
+func TestSessionFork(t *testing.T) {
+	chat := &scriptedChat{responses: []ChatResponse{answerResponse("Okay")}}
+	m := newSessionManager(t, chat)
+	session, err := m.NewSession(t.Context(), "model-2")
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if _, err := session.Send(t.Context(), "Hello"); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	fork := session.Fork()
+	if _, err := fork.Send(t.Context(), "Fork"); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if _, err := session.Send(t.Context(), "Original"); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	if got, want := session.History()[2].Content, "Original"; got != want {
+		t.Errorf("got original message %q, want %q", got, want)
+	}
+	if got, want := fork.History()[2].Content, "Fork"; got != want {
+		t.Errorf("got forked message %q, want %q", got, want)
+	}
+}
+
+// TestSessionPersistence verifies that a Session survives a JSON round trip.
+func TestSessionPersistence(t *testing.T) {
+	chat := &scriptedChat{responses: []ChatResponse{answerResponse("Okay")}}
+	m := newSessionManager(t, chat)
+	session, err := m.NewSession(t.Context(), "model-2",
+		WithSystemPrompt("Be brief."), WithContextWindow(4096), WithReplyTokens(256))
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if _, err := session.Send(t.Context(), "Hello"); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	restored, err := m.RestoreSession(t.Context(), data)
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	if got, want := restored.Model().ID, "model-2-npu:2"; got != want {
+		t.Errorf("got model %q, want %q", got, want)
+	}
+	if got, want := restored.SystemPrompt(), "Be brief."; got != want {
+		t.Errorf("got system prompt %q, want %q", got, want)
+	}
+	if got, want := restored.History(), session.History(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got history %+v, want %+v", got, want)
+	}
+	if _, err := restored.Send(t.Context(), "Again"); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if got, want := *chat.requests[1].MaxTokens, 256; got != want {
+		t.Errorf("got max_tokens %d, want %d", got, want)
+	}
+
+	if _, err := m.RestoreSession(t.Context(), []byte(`{"version":2}`)); err == nil || err.Error() != "unsupported session version 2" {
+		t.Errorf("got error %v, want unsupported version", err)
+	}
+}
+
+// TestNewSessionErrors verifies that invalid session configurations are rejected.
+func TestNewSessionErrors(t *testing.T) {
+	m := newSessionManager(t, &scriptedChat{})
+
+	tests := []struct {
+		name    string
+		opts    []SessionOption
+		wantErr string
+	}{
+		{
+			name:    "invalid_context_window",
+			opts:    []SessionOption{WithContextWindow(0)},
+			wantErr: "invalid context window of 0 tokens",
+		},
+		{
+			name:    "reply_exceeds_window",
+			opts:    []SessionOption{WithContextWindow(1024), WithReplyTokens(1024)},
+			wantErr: "1024 reply tokens do not fit into the context window of 1024 tokens",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := m.NewSession(t.Context(), "model-2", tc.opts...)
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("got error %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// TestSessionReplyTokens verifies that the default reply tokens are derived from the
+// context window and the model's limit, so models with large limits still work.
+func TestSessionReplyTokens(t *testing.T) {
+	tests := []struct {
+		name            string
+		maxOutputTokens int
+		opts            []SessionOption
+		want            int
+	}{
+		{name: "model_limit", maxOutputTokens: 1024, want: 1024},
+		{name: "large_model_limit", maxOutputTokens: 16384, want: DefaultContextWindow / 4},
+		{name: "unknown_model_limit", maxOutputTokens: 0, want: DefaultContextWindow / 4},
+		{name: "context_window", maxOutputTokens: 16384, opts: []SessionOption{WithContextWindow(128_000)}, want: 16384},
+		{name: "explicit", maxOutputTokens: 16384, opts: []SessionOption{WithReplyTokens(256)}, want: 256},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			catalog := buildCatalog(true)
+			for i := range catalog {
+				catalog[i].MaxOutputTokens = tc.maxOutputTokens
+			}
+			data, _ := json.Marshal(catalog)
+			chat := &scriptedChat{responses: []ChatResponse{answerResponse("Okay")}}
+			m := newSessionManagerWithCatalog(t, chat, mockJSON("/foundry/list", data))
+
+			session, err := m.NewSession(t.Context(), "model-2", tc.opts...)
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if _, err := session.Send(t.Context(), "Hello"); err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if got, want := *chat.requests[0].MaxTokens, tc.want; got != want {
+				t.Errorf("got max_tokens %d, want %d", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package foundrylocal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Shutdown gracefully releases the resources this Manager acquired. It unloads all
+// models that were loaded with this Manager's LoadModel method and have not been
+// unloaded since, leaving models loaded by other applications alone. Use
+// WithStopService to stop the Foundry Local service afterwards.
+//
+// Shutdown honors the deadline of ctx. Models that fail to unload, or that could
+// not be unloaded before ctx was done, are reported in a joined error and remain
+// tracked, so Shutdown can be called again. Models the runtime has already unloaded,
+// for example because their TTL expired, are not reported. If the service is not
+// running, Shutdown does not start it to unload models.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//	defer cancel()
+//	if err := manager.Shutdown(ctx, foundrylocal.WithStopService()); err != nil {
+//		log.Printf("Shutdown incomplete: %v", err)
+//	}
+func (m *Manager) Shutdown(ctx context.Context, opts ...ShutdownOption) error {
+	var config shutdownConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	var loaded []string
+	if m.IsServiceRunning() {
+		loaded = slices.Clone(m.loadedModels)
+	}
+
+	var errs []error
+	for _, modelID := range loaded {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to unload model %s: %w", modelID, err))
+			continue
+		}
+		err := m.UnloadModel(ctx, modelID, nil, config.force)
+		if errors.Is(err, ErrModelNotLoaded) {
+			// The runtime already unloaded the model, for example when its TTL expired.
+			m.Logger.DebugContext(ctx, "model was already unloaded", "modelID", modelID)
+			m.untrackLoadedModel(modelID)
+			continue
+		}
+		if err != nil {
+			m.Logger.ErrorContext(ctx, "failed to unload model", "modelID", modelID, "error", err)
+			errs = append(errs, fmt.Errorf("failed to unload model %s: %w", modelID, err))
+		}
+	}
+
+	if config.stopService {
+		if err := m.StopService(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop service: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// trackLoadedModel records that modelID was loaded by this Manager.
+func (m *Manager) trackLoadedModel(modelID string) {
+	if !slices.ContainsFunc(m.loadedModels, equalFold(modelID)) {
+		m.loadedModels = append(m.loadedModels, modelID)
+	}
+}
+
+// untrackLoadedModel removes modelID from the models loaded by this Manager.
+func (m *Manager) untrackLoadedModel(modelID string) {
+	m.loadedModels = slices.DeleteFunc(m.loadedModels, equalFold(modelID))
+}
+
+// equalFold returns a predicate that compares strings to s case-insensitively.
+func equalFold(s string) func(string) bool {
+	return func(other string) bool {
+		return strings.EqualFold(s, other)
+	}
+}
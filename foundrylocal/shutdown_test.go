@@ -0,0 +1,149 @@
+package foundrylocal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestShutdown verifies Shutdown unloads only the models loaded by the Manager,
+// reports unload failures, stops the service on request, and does not restart a
+// service that was already stopped.
+func TestShutdown(t *testing.T) {
+	tests := []struct {
+		name         string
+		preloaded    []string
+		failUnload   string
+		failStatus   int
+		stopFirst    bool
+		opts         []ShutdownOption
+		wantUnloaded []string
+		wantErr      bool
+		wantStops    int
+	}{
+		{
+			name:         "unload_all",
+			wantUnloaded: []string{"model-2-npu:2", "model-4-generic-gpu:1"},
+		},
+		{
+			name:         "unload_and_stop",
+			opts:         []ShutdownOption{WithStopService()},
+			wantUnloaded: []string{"model-2-npu:2", "model-4-generic-gpu:1"},
+			wantStops:    1,
+		},
+		{
+			name:         "unload_failure",
+			failUnload:   "model-2-npu:2",
+			failStatus:   http.StatusInternalServerError,
+			wantUnloaded: []string{"model-4-generic-gpu:1"},
+			wantErr:      true,
+		},
+		{
+			name:         "already_unloaded",
+			failUnload:   "model-2-npu:2",
+			failStatus:   http.StatusNotFound,
+			wantUnloaded: []string{"model-4-generic-gpu:1"},
+		},
+		{
+			name:         "loaded_by_other_application",
+			preloaded:    []string{"model-2-npu:2"},
+			wantUnloaded: []string{"model-4-generic-gpu:1"},
+		},
+		{
+			name:      "service_stopped",
+			stopFirst: true,
+			wantStops: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var unloaded []string
+			// Copy into a non-nil slice, so the service reports [] instead of null.
+			catalog := newHandler(
+				mockCatalog(true),
+				mockLocalModels("model-2-npu:2", "model-4-generic-gpu:1"),
+				mockLoadedModels(append([]string{}, tc.preloaded...)...))
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case strings.HasPrefix(r.URL.Path, "/openai/load/"):
+					w.Write([]byte(`{}`))
+				case strings.HasPrefix(r.URL.Path, "/openai/unload/"):
+					modelID := strings.TrimPrefix(r.URL.Path, "/openai/unload/")
+					if modelID == tc.failUnload {
+						http.Error(w, "unload failed", tc.failStatus)
+						return
+					}
+					unloaded = append(unloaded, modelID)
+				default:
+					catalog.ServeHTTP(w, r)
+				}
+			}))
+			defer srv.Close()
+			serviceURL, err := url.Parse(srv.URL)
+			if err != nil {
+				t.Fatalf("failed to parse service URL: %v", err)
+			}
+
+			launcher := &fakeLauncher{}
+			m := NewManager(WithServiceLauncher(launcher), WithOwnership(OwnershipExclusive), WithHTTPClient(srv.Client()))
+			m.serviceURL = serviceURL
+
+			for _, alias := range []string{"model-2", "model-4"} {
+				if _, err := m.LoadModel(t.Context(), alias, nil); err != nil {
+					t.Fatalf("failed to load model %s: %v", alias, err)
+				}
+			}
+
+			if tc.stopFirst {
+				if err := m.StopService(t.Context()); err != nil {
+					t.Fatalf("failed to stop service: %v", err)
+				}
+			}
+
+			err = m.Shutdown(t.Context(), tc.opts...)
+			if got, want := err != nil, tc.wantErr; got != want {
+				t.Fatalf("got error %v, want error %t", err, want)
+			}
+			if tc.wantErr && !strings.Contains(err.Error(), tc.failUnload) {
+				t.Errorf("got error %v, want it to name %s", err, tc.failUnload)
+			}
+			if got, want := unloaded, tc.wantUnloaded; !slices.Equal(got, want) {
+				t.Errorf("got unloaded models %v, want %v", got, want)
+			}
+			if got, want := launcher.stops, tc.wantStops; got != want {
+				t.Errorf("got %d stops, want %d", got, want)
+			}
+			if got, want := launcher.starts, 0; got != want {
+				t.Errorf("got %d starts, want %d", got, want)
+			}
+			if got, want := len(m.loadedModels) > 0, tc.wantErr; got != want {
+				t.Errorf("got tracked models %v, want tracked %t", m.loadedModels, want)
+			}
+		})
+	}
+}
+
+// TestShutdownDeadline verifies Shutdown reports models it could not unload
+// because the context was already done.
+func TestShutdownDeadline(t *testing.T) {
+	m := NewManager()
+	m.serviceURL = &url.URL{Scheme: "http", Host: "127.0.0.1:5273"}
+	m.trackLoadedModel("model-2-npu:2")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	err := m.Shutdown(ctx)
+	if got, want := err, context.Canceled; !errors.Is(got, want) {
+		t.Fatalf("got error %v, want %v", got, want)
+	}
+	if got, want := m.loadedModels, []string{"model-2-npu:2"}; !slices.Equal(got, want) {
+		t.Errorf("got tracked models %v, want %v", got, want)
+	}
+}
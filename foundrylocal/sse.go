@@ -0,0 +1,83 @@
+package foundrylocal
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"iter"
+	"strings"
+)
+
+// sseEvent is an event of a Server-Sent Events stream.
+type sseEvent struct {
+	// event is the event type. It is empty for unnamed events.
+	event string
+	// data contains the data lines of the event, joined by newlines.
+	data string
+	// id is the last event ID.
+	id string
+}
+
+// readEvents parses a Server-Sent Events stream as described in
+// https://html.spec.whatwg.org/multipage/server-sent-events.html and yields its
+// events. Lines may end with "\n" or "\r\n", and lines split across reads are
+// reassembled. Comments and events without data are skipped. Unlike the
+// specification, an event that is not terminated by a blank line at the end of
+// the stream is still dispatched, because some servers omit the final newline.
+// Read errors other than io.EOF are yielded once and end the sequence.
+func readEvents(r io.Reader) iter.Seq2[sseEvent, error] {
+	return func(yield func(sseEvent, error) bool) {
+		reader := bufio.NewReader(r)
+		var (
+			event sseEvent
+			data  []string
+		)
+		dispatch := func() bool {
+			defer func() {
+				event.event, data = "", nil
+			}()
+			if data == nil {
+				return true
+			}
+			event.data = strings.Join(data, "\n")
+			return yield(event, nil)
+		}
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil && !errors.Is(err, io.EOF) {
+				yield(sseEvent{}, err)
+				return
+			}
+			eof := err != nil
+			line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+
+			switch {
+			case line == "" && !eof:
+				if !dispatch() {
+					return
+				}
+			case strings.HasPrefix(line, ":"):
+				// Comment, often used as a keep-alive.
+			case line != "":
+				field, value, _ := strings.Cut(line, ":")
+				value = strings.TrimPrefix(value, " ")
+				switch field {
+				case "event":
+					event.event = value
+				case "data":
+					data = append(data, value)
+				case "id":
+					if !strings.Contains(value, "\x00") {
+						event.id = value
+					}
+				}
+			}
+
+			if eof {
+				dispatch()
+				return
+			}
+		}
+	}
+}
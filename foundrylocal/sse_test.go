@@ -0,0 +1,98 @@
+package foundrylocal
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// TestReadEvents verifies parsing of Server-Sent Events streams.
+func TestReadEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		stream  string
+		oneByte bool
+		want    []sseEvent
+	}{
+		{
+			name:   "data_events",
+			stream: "data: {\"a\":1}\n\ndata: [DONE]\n\n",
+			want:   []sseEvent{{data: `{"a":1}`}, {data: "[DONE]"}},
+		},
+		{
+			name:   "crlf_and_no_space",
+			stream: "data:one\r\n\r\ndata:  two\r\n\r\n",
+			want:   []sseEvent{{data: "one"}, {data: " two"}},
+		},
+		{
+			name:   "multiline_data",
+			stream: "data: first\ndata: second\n\n",
+			want:   []sseEvent{{data: "first\nsecond"}},
+		},
+		{
+			name:   "fields_and_comments",
+			stream: ": keep-alive\n\nevent: update\nid: 7\nretry: 1000\ndata: x\n\nevent: ignored\n\n",
+			want:   []sseEvent{{event: "update", id: "7", data: "x"}},
+		},
+		{
+			name:    "partial_lines",
+			stream:  "data: {\"content\":\"Autumn\"}\n\ndata: [DONE]\n\n",
+			oneByte: true,
+			want:    []sseEvent{{data: `{"content":"Autumn"}`}, {data: "[DONE]"}},
+		},
+		{
+			name:   "missing_final_newline",
+			stream: "data: one\n\ndata: [DONE]",
+			want:   []sseEvent{{data: "one"}, {data: "[DONE]"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var r io.Reader = strings.NewReader(tc.stream)
+			if tc.oneByte {
+				r = iotest.OneByteReader(r)
+			}
+			var got []sseEvent
+			for event, err := range readEvents(r) {
+				if err != nil {
+					t.Fatalf("got error %v, want nil", err)
+				}
+				got = append(got, event)
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("got events %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestReadEventsError verifies that read errors end the sequence.
+func TestReadEventsError(t *testing.T) {
+	boom := errors.New("boom")
+	r := io.MultiReader(strings.NewReader("data: one\n\n"), iotest.ErrReader(boom))
+
+	var (
+		events int
+		errs   []error
+	)
+	for _, err := range readEvents(r) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		events++
+	}
+	if got, want := events, 1; got != want {
+		t.Errorf("got %d events, want %d", got, want)
+	}
+	if got, want := len(errs), 1; got != want {
+		t.Fatalf("got %d errors, want %d", got, want)
+	}
+	if !errors.Is(errs[0], boom) {
+		t.Errorf("got error %v, want %v", errs[0], boom)
+	}
+}
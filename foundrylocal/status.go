@@ -0,0 +1,158 @@
+package foundrylocal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+)
+
+// runtimeVersionPattern extracts a Foundry Local runtime version such as "0.8.117"
+// from the output of 'foundry --version'.
+var runtimeVersionPattern = regexp.MustCompile(`\d+\.\d+\.\d+`)
+
+// CommandRunner is implemented by ServiceLaunchers that can run arbitrary
+// foundry commands, such as CLILauncher. The Manager uses it for operations
+// beyond starting, stopping, and querying the service, for example to detect
+// the runtime version.
+type CommandRunner interface {
+	// Run executes the foundry command-line tool with the given arguments
+	// and returns its output.
+	Run(ctx context.Context, args ...string) (string, error)
+}
+
+// ServiceStatus describes the state of the Foundry Local service.
+type ServiceStatus struct {
+	// Running indicates whether the service is running and reachable.
+	Running bool
+	// Endpoint is the base URL of the service.
+	Endpoint *url.URL
+	// RuntimeVersion is the Foundry Local runtime version, such as "0.8.117".
+	// It is empty if the version cannot be determined, for example when the
+	// Manager is attached to a service with WithEndpoint.
+	RuntimeVersion string
+	// ModelDirPath is the directory where the service caches models.
+	ModelDirPath string
+	// LoadedModels contains the IDs of models currently loaded in memory.
+	LoadedModels []string
+}
+
+// Status reports the state of the Foundry Local service. The endpoint is resolved
+// from the ServiceLauncher's status report (or taken from WithEndpoint) and the
+// details are read from the service's status and loaded models resources.
+// Status does not start the service. If no service is running or reachable,
+// it returns ErrServiceNotRunning.
+//
+// Example:
+//
+//	status, err := manager.Status(ctx)
+//	if errors.Is(err, foundrylocal.ErrServiceNotRunning) {
+//		fmt.Println("Foundry Local is not running")
+//		return
+//	}
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Printf("Foundry Local %s is running at %s\n", status.RuntimeVersion, status.Endpoint)
+func (m *Manager) Status(ctx context.Context) (ServiceStatus, error) {
+	endpoint := m.endpoint
+	if endpoint == nil {
+		var err error
+		if endpoint, err = m.statusEndpoint(ctx); err != nil {
+			return ServiceStatus{}, err
+		}
+	}
+
+	statusCtx, cancel := m.operationContext(ctx, classMetadata)
+	status, err := readStatus(statusCtx, m.client, endpoint)
+	cancel()
+	if err != nil {
+		return ServiceStatus{}, err
+	}
+
+	if m.endpoint == nil {
+		if status.RuntimeVersion, err = m.detectRuntimeVersion(ctx); err != nil {
+			m.Logger.DebugContext(ctx, "failed to detect runtime version", "error", err)
+		}
+	}
+	return status, nil
+}
+
+// readStatus reads the status and the loaded models of the service at endpoint.
+func readStatus(ctx context.Context, client *http.Client, endpoint *url.URL) (ServiceStatus, error) {
+	var result struct {
+		ModelDirPath string `json:"modelDirPath"`
+	}
+	if err := getJSON(ctx, client, "Status", endpoint.JoinPath("openai", "status"), &result); err != nil {
+		return ServiceStatus{}, err
+	}
+
+	var loaded []string
+	if err := getJSON(ctx, client, "Status", endpoint.JoinPath("openai", "loadedmodels"), &loaded); err != nil {
+		return ServiceStatus{}, err
+	}
+	if loaded == nil {
+		loaded = []string{}
+	}
+
+	return ServiceStatus{
+		Running:      true,
+		Endpoint:     endpoint,
+		ModelDirPath: result.ModelDirPath,
+		LoadedModels: loaded,
+	}, nil
+}
+
+// detectRuntimeVersion detects the Foundry Local runtime version by running 'foundry --version'.
+// It returns an empty string if the ServiceLauncher cannot run commands.
+func (m *Manager) detectRuntimeVersion(ctx context.Context) (string, error) {
+	if _, ok := m.launcher.(CommandRunner); !ok {
+		return "", nil
+	}
+
+	out, err := m.invokeFoundry(ctx, "--version")
+	if err != nil {
+		return "", err
+	}
+	version := runtimeVersionPattern.FindString(out)
+	if version == "" {
+		return "", fmt.Errorf("no version found in %q", out)
+	}
+	return version, nil
+}
+
+// getJSON issues a GET request to u on behalf of operation op and decodes the JSON
+// response into v. Errors caused by an unreachable service are reported as
+// ErrServiceNotRunning, non-success responses as *APIError.
+func getJSON(ctx context.Context, client *http.Client, op string, u *url.URL, v any) error {
+	ctx = contextWithOperation(ctx, Operation{Name: op})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if isUnreachable(err) {
+			return fmt.Errorf("%w: %w", ErrServiceNotRunning, err)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if !ensureSuccessStatusCode(resp) {
+		return newAPIError(op, resp)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// isUnreachable reports whether err was caused by failing to connect to the service,
+// for example because nothing is listening on the endpoint.
+func isUnreachable(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,110 @@
+package foundrylocal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeRunner is a fakeLauncher that can also run arbitrary foundry commands,
+// returning scripted output keyed by the joined command-line arguments.
+type fakeRunner struct {
+	fakeLauncher
+	outputs map[string]string
+	calls   [][]string
+}
+
+func (r *fakeRunner) Run(ctx context.Context, args ...string) (string, error) {
+	r.calls = append(r.calls, args)
+	out, ok := r.outputs[strings.Join(args, " ")]
+	if !ok {
+		return "", errors.New("unexpected command")
+	}
+	return out, nil
+}
+
+// TestStatus verifies Status combines the launcher's status report, the runtime
+// version, and the service's status resources, and reports ErrServiceNotRunning
+// when no service is running or reachable or the status report fails.
+func TestStatus(t *testing.T) {
+	srv := httptest.NewServer(newHandler(
+		mockJSON("/openai/status", json.RawMessage(`{"modelDirPath": "/models"}`)),
+		mockLoadedModels("model-2-npu:1")))
+	defer srv.Close()
+
+	closed := httptest.NewServer(newHandler())
+	closed.Close()
+
+	tests := []struct {
+		name        string
+		status      string
+		statusErr   error
+		wantVersion string
+		wantLoaded  []string
+		err         error
+	}{
+		{
+			name:        "service_running",
+			status:      "🟢 Model management service is running on " + srv.URL + "/openai/status\n",
+			wantVersion: "0.8.117",
+			wantLoaded:  []string{"model-2-npu:1"},
+		},
+		{
+			name:   "service_not_running",
+			status: "🔴 Model management service is not running!\n",
+			err:    ErrServiceNotRunning,
+		},
+		{
+			name:   "service_unreachable",
+			status: "🟢 Model management service is running on " + closed.URL + "/openai/status\n",
+			err:    ErrServiceNotRunning,
+		}, {
+			name:      "status_fails",
+			statusErr: errors.New("exit status 1"),
+			err:       ErrServiceNotRunning,
+		},
+		{
+			name:      "not_installed",
+			statusErr: ErrFoundryNotInstalled,
+			err:       ErrFoundryNotInstalled,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			launcher := &fakeRunner{
+				fakeLauncher: fakeLauncher{status: tc.status, statusErr: tc.statusErr},
+				outputs:      map[string]string{"--version": "0.8.117+67073234e7\n"},
+			}
+			m := NewManager(WithServiceLauncher(launcher))
+
+			status, err := m.Status(t.Context())
+			if got, want := err, tc.err; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+			if tc.err != nil {
+				return
+			}
+
+			if got, want := status.Running, true; got != want {
+				t.Errorf("got running %t, want %t", got, want)
+			}
+			if got, want := status.Endpoint.String(), srv.URL; got != want {
+				t.Errorf("got endpoint %q, want %q", got, want)
+			}
+			if got, want := status.RuntimeVersion, tc.wantVersion; got != want {
+				t.Errorf("got runtime version %q, want %q", got, want)
+			}
+			if got, want := status.ModelDirPath, "/models"; got != want {
+				t.Errorf("got model directory %q, want %q", got, want)
+			}
+			if got, want := status.LoadedModels, tc.wantLoaded; !slices.Equal(got, want) {
+				t.Errorf("got loaded models %v, want %v", got, want)
+			}
+		})
+	}
+}
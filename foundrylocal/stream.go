@@ -0,0 +1,229 @@
+package foundrylocal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+)
+
+// ChatDelta is the part of a message contained in a ChatChunk.
+type ChatDelta struct {
+	// Role is the author of the message. It is usually only set in the first chunk.
+	Role Role `json:"role,omitempty"`
+	// Content is the next piece of the message text.
+	Content string `json:"content,omitempty"`
+	// ToolCalls contains the next parts of the requested tool calls.
+	ToolCalls []ToolCallDelta `json:"tool_calls,omitempty"`
+}
+
+// ToolCallDelta is a part of a streamed tool call. The ID, type, and function name
+// are usually only set in the first part, while the arguments are streamed in
+// pieces.
+type ToolCallDelta struct {
+	// Index is the position of the tool call in the message.
+	Index int `json:"index"`
+	// ID identifies the call.
+	ID string `json:"id,omitempty"`
+	// Type is the type of the tool.
+	Type string `json:"type,omitempty"`
+	// Function contains the next parts of the function name and arguments.
+	Function FunctionCall `json:"function"`
+}
+
+// ChatChunkChoice is a choice of a ChatChunk.
+type ChatChunkChoice struct {
+	// Index is the index of the choice.
+	Index int `json:"index"`
+	// Delta contains the next part of the message.
+	Delta ChatDelta `json:"delta"`
+	// FinishReason describes why generation stopped. It is only set in the last
+	// chunk of a choice.
+	FinishReason FinishReason `json:"finish_reason,omitempty"`
+}
+
+// ChatChunk is a part of a streamed chat completion.
+type ChatChunk struct {
+	// ID is the unique identifier of the completion. All chunks share the same ID.
+	ID string `json:"id"`
+	// Created is the Unix time in seconds when the completion was created.
+	Created int64 `json:"created"`
+	// Model is the ID of the model that generates the completion.
+	Model string `json:"model"`
+	// Choices contains the parts of the generated choices.
+	Choices []ChatChunkChoice `json:"choices"`
+	// Usage reports the number of tokens used. Only some runtimes report it,
+	// usually in the last chunk.
+	Usage *Usage `json:"usage,omitempty"`
+}
+
+// streamDone is the data of the event that ends a chat completion stream.
+const streamDone = "[DONE]"
+
+// ChatStream sends a streaming chat completion request and returns an iterator
+// over the chunks of the response. Like Chat, it requires a loaded model and uses
+// the Manager's ApiKey. The iterator yields a non-nil error at most once, as its
+// last element, for example if ctx is canceled or the stream ends unexpectedly.
+// Breaking out of the loop closes the stream.
+//
+// Some Foundry Local runtimes end the stream without reporting a finish reason
+// (see https://github.com/microsoft/Foundry-Local/issues/299). In this case,
+// ChatStream yields a final chunk with FinishReasonStop for every choice that
+// did not report one, so callers can rely on every choice finishing.
+//
+// Use ChatAccumulator to assemble the chunks into a ChatResponse.
+//
+// Example:
+//
+//	var acc foundrylocal.ChatAccumulator
+//	for chunk, err := range manager.ChatStream(ctx, req) {
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		acc.Add(chunk)
+//		for _, choice := range chunk.Choices {
+//			fmt.Print(choice.Delta.Content)
+//		}
+//	}
+//	fmt.Println()
+//	log.Printf("finish reason: %s", acc.Response().Choices[0].FinishReason)
+func (m *Manager) ChatStream(ctx context.Context, req ChatRequest) iter.Seq2[ChatChunk, error] {
+	return func(yield func(ChatChunk, error) bool) {
+		resp, err := m.sendChat(ctx, "ChatStream", req, true)
+		if err != nil {
+			yield(ChatChunk{}, err)
+			return
+		}
+		defer resp.Body.Close()
+
+		for chunk, err := range readChunks(resp.Body) {
+			if err != nil && ctx.Err() != nil && !errors.Is(err, ctx.Err()) {
+				err = fmt.Errorf("%w: %w", ctx.Err(), err)
+			}
+			if !yield(chunk, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readChunks decodes the chat completion chunks of an SSE stream. The stream must
+// end with a "[DONE]" event. Choices that did not report a finish reason are
+// finished with FinishReasonStop by a final synthetic chunk.
+func readChunks(r io.Reader) iter.Seq2[ChatChunk, error] {
+	return func(yield func(ChatChunk, error) bool) {
+		var (
+			last       ChatChunk
+			unfinished []int
+		)
+		for event, err := range readEvents(r) {
+			if err != nil {
+				yield(ChatChunk{}, err)
+				return
+			}
+			if event.data == streamDone {
+				if len(unfinished) > 0 {
+					final := ChatChunk{ID: last.ID, Created: last.Created, Model: last.Model}
+					for _, index := range unfinished {
+						final.Choices = append(final.Choices, ChatChunkChoice{Index: index, FinishReason: FinishReasonStop})
+					}
+					yield(final, nil)
+				}
+				return
+			}
+
+			var chunk ChatChunk
+			if err := json.Unmarshal([]byte(event.data), &chunk); err != nil {
+				yield(ChatChunk{}, fmt.Errorf("failed to decode chat completion chunk %q: %w", event.data, err))
+				return
+			}
+			for _, choice := range chunk.Choices {
+				finished := choice.FinishReason != ""
+				i := slices.Index(unfinished, choice.Index)
+				switch {
+				case finished && i >= 0:
+					unfinished = slices.Delete(unfinished, i, i+1)
+				case !finished && i < 0:
+					unfinished = append(unfinished, choice.Index)
+				}
+			}
+			last = chunk
+			if !yield(chunk, nil) {
+				return
+			}
+		}
+		yield(ChatChunk{}, fmt.Errorf("chat completion stream ended before %s: %w", streamDone, io.ErrUnexpectedEOF))
+	}
+}
+
+// ChatAccumulator assembles streamed chat completion chunks into a ChatResponse.
+// The zero value is ready to use.
+type ChatAccumulator struct {
+	resp ChatResponse
+}
+
+// Add merges chunk into the accumulated response.
+func (a *ChatAccumulator) Add(chunk ChatChunk) {
+	if chunk.ID != "" {
+		a.resp.ID = chunk.ID
+	}
+	if chunk.Created != 0 {
+		a.resp.Created = chunk.Created
+	}
+	if chunk.Model != "" {
+		a.resp.Model = chunk.Model
+	}
+	if chunk.Usage != nil {
+		a.resp.Usage = *chunk.Usage
+	}
+
+	for _, delta := range chunk.Choices {
+		choice := a.choice(delta.Index)
+		if delta.Delta.Role != "" {
+			choice.Message.Role = delta.Delta.Role
+		}
+		choice.Message.Content += delta.Delta.Content
+		for _, call := range delta.Delta.ToolCalls {
+			for len(choice.Message.ToolCalls) <= call.Index {
+				choice.Message.ToolCalls = append(choice.Message.ToolCalls, ToolCall{})
+			}
+			toolCall := &choice.Message.ToolCalls[call.Index]
+			if call.ID != "" {
+				toolCall.ID = call.ID
+			}
+			if call.Type != "" {
+				toolCall.Type = call.Type
+			}
+			toolCall.Function.Name += call.Function.Name
+			toolCall.Function.Arguments += call.Function.Arguments
+		}
+		if delta.FinishReason != "" {
+			choice.FinishReason = delta.FinishReason
+		}
+	}
+}
+
+// choice returns the accumulated choice with index, adding it if necessary.
+func (a *ChatAccumulator) choice(index int) *ChatChoice {
+	for i := range a.resp.Choices {
+		if a.resp.Choices[i].Index == index {
+			return &a.resp.Choices[i]
+		}
+	}
+	a.resp.Choices = append(a.resp.Choices, ChatChoice{Index: index, Message: ChatMessage{Role: RoleAssistant}})
+	return &a.resp.Choices[len(a.resp.Choices)-1]
+}
+
+// Response returns the response accumulated so far.
+func (a *ChatAccumulator) Response() ChatResponse {
+	resp := a.resp
+	resp.Choices = slices.Clone(a.resp.Choices)
+	for i := range resp.Choices {
+		resp.Choices[i].Message.ToolCalls = slices.Clone(resp.Choices[i].Message.ToolCalls)
+	}
+	slices.SortFunc(resp.Choices, func(x, y ChatChoice) int { return x.Index - y.Index })
+	return resp
+}
@@ -0,0 +1,185 @@
+package foundrylocal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// sseChunks renders chunk payloads as a Server-Sent Events stream.
+func sseChunks(payloads ...string) string {
+	var b strings.Builder
+	for _, payload := range payloads {
+		b.WriteString("data: " + payload + "\n\n")
+	}
+	return b.String()
+}
+
+// TestChatStream verifies streamed chat completions, including runtimes that omit
+// the finish reason and streams that end unexpectedly.
+func TestChatStream(t *testing.T) {
+	tests := []struct {
+		name       string
+		stream     string
+		wantText   string
+		wantFinish FinishReason
+		wantChunks int
+		wantUsage  Usage
+		wantErr    error
+	}{
+		{
+			name: "finish_reason",
+			stream: sseChunks(
+				`{"id":"c1","model":"model-2-npu:2","choices":[{"index":0,"delta":{"role":"assistant","content":"Autumn"}}]}`,
+				`{"id":"c1","model":"model-2-npu:2","choices":[{"index":0,"delta":{"content":" moon"}}]}`,
+				`{"id":"c1","model":"model-2-npu:2","choices":[{"index":0,"delta":{},"finish_reason":"length"}],"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}`,
+				"[DONE]"),
+			wantText:   "Autumn moon",
+			wantFinish: FinishReasonLength,
+			wantChunks: 3,
+			wantUsage:  Usage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+		},
+		{
+			name: "missing_finish_reason",
+			stream: sseChunks(
+				`{"id":"c1","model":"model-2-npu:2","choices":[{"index":0,"delta":{"role":"assistant","content":"Autumn"}}]}`,
+				`{"id":"c1","model":"model-2-npu:2","choices":[{"index":0,"delta":{"content":" moon"}}]}`,
+				"[DONE]"),
+			wantText:   "Autumn moon",
+			wantFinish: FinishReasonStop,
+			wantChunks: 3,
+		},
+		{
+			name: "missing_done",
+			stream: sseChunks(
+				`{"id":"c1","model":"model-2-npu:2","choices":[{"index":0,"delta":{"content":"Autumn"}}]}`),
+			wantText:   "Autumn",
+			wantChunks: 1,
+			wantErr:    io.ErrUnexpectedEOF,
+		},
+		{
+			name:       "invalid_chunk",
+			stream:     sseChunks(`{"choices":`),
+			wantChunks: 0,
+			wantErr:    errors.New("failed to decode chat completion chunk"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				body   map[string]any
+				header http.Header
+			)
+			srv := httptest.NewServer(mockChat(t, http.StatusOK, tc.stream, &body, &header))
+			defer srv.Close()
+			serviceURL, err := url.Parse(srv.URL)
+			if err != nil {
+				t.Fatalf("failed to parse service URL: %v", err)
+			}
+
+			m := NewManager(WithHTTPClient(srv.Client()))
+			m.serviceURL = serviceURL
+
+			var (
+				acc    ChatAccumulator
+				chunks int
+			)
+			req := ChatRequest{Model: "model-2", Messages: []ChatMessage{UserMessage("Write me a haiku")}}
+			for chunk, err := range m.ChatStream(t.Context(), req) {
+				if err != nil {
+					if tc.wantErr == nil {
+						t.Fatalf("got error %v, want nil", err)
+					}
+					if !errors.Is(err, tc.wantErr) && !strings.Contains(err.Error(), tc.wantErr.Error()) {
+						t.Fatalf("got error %v, want %v", err, tc.wantErr)
+					}
+					break
+				}
+				chunks++
+				acc.Add(chunk)
+			}
+
+			if got, want := body["stream"], true; got != want {
+				t.Errorf("got stream %v, want %v", got, want)
+			}
+			if got, want := chunks, tc.wantChunks; got != want {
+				t.Errorf("got %d chunks, want %d", got, want)
+			}
+			if tc.wantChunks == 0 {
+				return
+			}
+			resp := acc.Response()
+			if got, want := resp.Message(), AssistantMessage(tc.wantText); !reflect.DeepEqual(got, want) {
+				t.Errorf("got message %+v, want %+v", got, want)
+			}
+			if got, want := resp.Choices[0].FinishReason, tc.wantFinish; got != want {
+				t.Errorf("got finish reason %q, want %q", got, want)
+			}
+			if got, want := resp.Usage, tc.wantUsage; got != want {
+				t.Errorf("got usage %+v, want %+v", got, want)
+			}
+			if got, want := resp.ID, "c1"; got != want {
+				t.Errorf("got ID %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+// TestChatStreamCanceled verifies that canceling the context ends a stream that
+// is still open and reports the cancellation.
+func TestChatStreamCanceled(t *testing.T) {
+	catalog := newHandler(mockCatalog(true))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			catalog.ServeHTTP(w, r)
+			return
+		}
+		chunk, _ := json.Marshal(ChatChunk{ID: "c1", Choices: []ChatChunkChoice{{Delta: ChatDelta{Content: "Autumn"}}}})
+		w.Write([]byte("data: " + string(chunk) + "\n\n"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+	serviceURL, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to parse service URL: %v", err)
+	}
+
+	m := NewManager(WithHTTPClient(srv.Client()))
+	m.serviceURL = serviceURL
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	var (
+		chunks int
+		errs   []error
+	)
+	req := ChatRequest{Model: "model-2", Messages: []ChatMessage{UserMessage("Write me a haiku")}}
+	for _, err := range m.ChatStream(ctx, req) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		chunks++
+		cancel()
+	}
+
+	if got, want := chunks, 1; got != want {
+		t.Errorf("got %d chunks, want %d", got, want)
+	}
+	if got, want := len(errs), 1; got != want {
+		t.Fatalf("got %d errors, want %d", got, want)
+	}
+	if !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("got error %v, want %v", errs[0], context.Canceled)
+	}
+}
@@ -0,0 +1,211 @@
+package foundrylocal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// fencePattern matches the contents of a Markdown code block.
+var fencePattern = regexp.MustCompile("(?s)```[a-zA-Z]*\\s*\\n?(.*?)```")
+
+// ChatJSONOption configures ChatJSON.
+type ChatJSONOption func(*chatJSONConfig)
+
+type chatJSONConfig struct {
+	retries        int
+	responseFormat bool
+}
+
+// WithJSONRetries sets how often ChatJSON asks the model again after it returned
+// invalid JSON. The default is 2 retries. Negative values are treated as 0.
+//
+// Example:
+//
+//	recipe, err := foundrylocal.ChatJSON[Recipe](ctx, manager, req, foundrylocal.WithJSONRetries(4))
+func WithJSONRetries(retries int) ChatJSONOption {
+	return func(cfg *chatJSONConfig) {
+		cfg.retries = retries
+	}
+}
+
+// WithoutResponseFormat makes ChatJSON rely on prompt instructions only and not
+// send a response_format, for runtimes that accept but ignore or mishandle it.
+//
+// Example:
+//
+//	recipe, err := foundrylocal.ChatJSON[Recipe](ctx, manager, req, foundrylocal.WithoutResponseFormat())
+func WithoutResponseFormat() ChatJSONOption {
+	return func(cfg *chatJSONConfig) {
+		cfg.responseFormat = false
+	}
+}
+
+// ChatJSON sends req and decodes the model's answer into a value of type T, which
+// must be a struct, a map with string keys, or a slice or array. The JSON Schema of
+// T is derived like with SchemaFor and sent to the model both as a system message
+// and, if it describes an object, as a response_format, since response formats
+// require an object at the root of the schema. If the runtime rejects
+// the response_format with an error that mentions it, ChatJSON falls back to the
+// instructions in the system message. If that request fails too, the rejection is
+// returned.
+//
+// The JSON is extracted from the answer even if the model wraps it in a Markdown
+// code block or surrounds it with prose, and it is validated against the schema.
+// If the answer is invalid, the validation error is sent back to the model and it
+// is asked again, up to the number of retries set with WithJSONRetries. If all
+// attempts fail, the error wraps ErrInvalidJSONResponse.
+//
+// Example:
+//
+//	type Recipe struct {
+//		Name        string   `json:"name"`
+//		Ingredients []string `json:"ingredients"`
+//		Difficulty  string   `json:"difficulty" enum:"easy,medium,hard"`
+//	}
+//
+//	recipe, err := foundrylocal.ChatJSON[Recipe](ctx, manager, foundrylocal.ChatRequest{
+//		Model:    "phi-4-mini",
+//		Messages: []foundrylocal.ChatMessage{foundrylocal.UserMessage("Suggest a pancake recipe.")},
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(recipe.Name)
+func ChatJSON[T any](ctx context.Context, m *Manager, req ChatRequest, opts ...ChatJSONOption) (T, error) {
+	var zero T
+	config := chatJSONConfig{
+		retries:        2,
+		responseFormat: true,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	config.retries = max(config.retries, 0)
+
+	schema, err := schemaOf(reflect.TypeFor[T](), nil)
+	if err != nil {
+		return zero, err
+	}
+	if schema.Type != "object" && schema.Type != "array" {
+		return zero, fmt.Errorf("cannot decode JSON into %s: not an object or array type", reflect.TypeFor[T]())
+	}
+	config.responseFormat = config.responseFormat && schema.Type == "object"
+	schemaJSON, err := json.Marshal(schema)
+	if err != nil {
+		return zero, err
+	}
+	instructions := SystemMessage("Respond only with a JSON value that matches the following JSON Schema. " +
+		"Do not add explanations or Markdown code fences.\n" + string(schemaJSON))
+	req.Messages = append([]ChatMessage{instructions}, req.Messages...)
+
+	var formatErr error
+	for attempt := 0; ; attempt++ {
+		if config.responseFormat {
+			req.ResponseFormat = &ResponseFormat{
+				Type:       "json_schema",
+				JSONSchema: &ResponseSchema{Name: "response", Schema: schema},
+			}
+		} else {
+			req.ResponseFormat = nil
+		}
+
+		resp, err := m.Chat(ctx, req)
+		if err != nil && config.responseFormat && responseFormatRejected(err) {
+			m.Logger.InfoContext(ctx, "response format rejected, falling back to prompt instructions", "model", req.Model)
+			config.responseFormat = false
+			formatErr = err
+			attempt--
+			continue
+		}
+		if err != nil && formatErr != nil {
+			// The fallback failed as well, so the rejection may have had another cause.
+			m.Logger.WarnContext(ctx, "request without response format failed", "error", err)
+			return zero, formatErr
+		}
+		if err != nil {
+			return zero, err
+		}
+		formatErr = nil
+
+		content := resp.Message().Content
+		value, err := decodeJSON[T](content, schema)
+		if err == nil {
+			return value, nil
+		}
+		if attempt >= config.retries {
+			return zero, fmt.Errorf("%w after %d attempts: %w", ErrInvalidJSONResponse, attempt+1, err)
+		}
+		m.Logger.DebugContext(ctx, "model returned invalid JSON, retrying", "attempt", attempt+1, "error", err)
+		req.Messages = append(req.Messages,
+			AssistantMessage(content),
+			UserMessage("Your response is invalid: "+err.Error()+"\nRespond again with only the corrected JSON."))
+	}
+}
+
+// responseFormatRejected reports whether err indicates that the runtime does not
+// support the response_format of a request. Only client errors that mention the
+// response format count, so that unrelated errors are not retried without it.
+func responseFormatRejected(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusNotImplemented:
+		body := strings.ToLower(apiErr.Body)
+		return strings.Contains(body, "response_format") || strings.Contains(body, "json_schema")
+	}
+	return false
+}
+
+// decodeJSON extracts the JSON value from content, validates it against schema,
+// and decodes it into a T.
+func decodeJSON[T any](content string, schema *JSONSchema) (T, error) {
+	var value T
+	data, err := extractJSON(content)
+	if err != nil {
+		return value, err
+	}
+	if err := schema.Validate(data); err != nil {
+		return value, err
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return value, err
+	}
+	return value, nil
+}
+
+// extractJSON returns the first JSON object or array in content. If content
+// contains a Markdown code block, only the block is searched. Text before and
+// after the JSON value is ignored.
+func extractJSON(content string) ([]byte, error) {
+	if match := fencePattern.FindStringSubmatch(content); match != nil {
+		content = match[1]
+	}
+
+	var firstErr error
+	for i := 0; i < len(content); i++ {
+		if content[i] != '{' && content[i] != '[' {
+			continue
+		}
+		var raw json.RawMessage
+		err := json.NewDecoder(strings.NewReader(content[i:])).Decode(&raw)
+		if err == nil {
+			return bytes.TrimSpace(raw), nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if firstErr != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", firstErr)
+	}
+	return nil, errors.New("no JSON value found in response")
+}
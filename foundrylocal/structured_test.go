@@ -0,0 +1,292 @@
+package foundrylocal
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type recipe struct {
+	Name       string `json:"name"`
+	Difficulty string `json:"difficulty" enum:"easy,medium,hard"`
+}
+
+// TestExtractJSON verifies that JSON is extracted from fenced code blocks and prose.
+func TestExtractJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr string
+	}{
+		{name: "plain", content: `{"name":"Pancakes"}`, want: `{"name":"Pancakes"}`},
+		{name: "fenced", content: "Sure!\n```json\n{\"name\": \"Pancakes\"}\n```\nEnjoy!", want: `{"name": "Pancakes"}`},
+		{name: "prose", content: `Here is the recipe: {"name":"Pancakes"} Let me know if you need more.`, want: `{"name":"Pancakes"}`},
+		{name: "braces_in_prose", content: `Use {name} as key: {"name":"Pancakes"}`, want: `{"name":"Pancakes"}`},
+		{name: "array", content: `[1, 2]`, want: `[1, 2]`},
+		{name: "no_json", content: "I cannot help with that.", wantErr: "no JSON value found in response"},
+		{name: "truncated", content: `{"name":"Pan`, wantErr: "invalid JSON: unexpected EOF"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := extractJSON(tc.content)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("got error %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// formatChat answers chat completion requests with the given contents in order,
+// repeating the last one, and records the decoded requests. If rejectBody is set,
+// requests with a response_format fail with 400 Bad Request and rejectBody. If
+// failPlain is set, requests without a response_format fail with 500.
+type formatChat struct {
+	contents   []string
+	rejectBody string
+	failPlain  bool
+	requests   []map[string]any
+}
+
+func (f *formatChat) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	var req map[string]any
+	json.Unmarshal(body, &req)
+	f.requests = append(f.requests, req)
+	_, hasFormat := req["response_format"]
+	if hasFormat && f.rejectBody != "" {
+		http.Error(w, f.rejectBody, http.StatusBadRequest)
+		return
+	}
+	if !hasFormat && f.failPlain {
+		http.Error(w, `{"error":"internal error"}`, http.StatusInternalServerError)
+		return
+	}
+	answered := 0
+	for _, req := range f.requests {
+		if _, ok := req["response_format"]; !ok || f.rejectBody == "" {
+			answered++
+		}
+	}
+	content := f.contents[min(answered, len(f.contents))-1]
+	json.NewEncoder(w).Encode(answerResponse(content))
+}
+
+// TestChatJSON verifies that ChatJSON decodes structured answers, retries invalid
+// answers, and falls back to prompt instructions.
+func TestChatJSON(t *testing.T) {
+	tests := []struct {
+		name         string
+		contents     []string
+		rejectBody   string
+		failPlain    bool
+		opts         []ChatJSONOption
+		want         recipe
+		wantErr      error
+		wantStatus   int
+		wantRequests int
+		wantFormat   []bool
+		wantFeedback string
+	}{
+		{
+			name:         "valid",
+			contents:     []string{`{"name":"Pancakes","difficulty":"easy"}`},
+			want:         recipe{Name: "Pancakes", Difficulty: "easy"},
+			wantRequests: 1,
+			wantFormat:   []bool{true},
+		},
+		{
+			name:         "fenced_with_prose",
+			contents:     []string{"Here you go:\n```json\n{\"name\":\"Pancakes\",\"difficulty\":\"easy\"}\n```"},
+			want:         recipe{Name: "Pancakes", Difficulty: "easy"},
+			wantRequests: 1,
+			wantFormat:   []bool{true},
+		},
+		{
+			name:         "retry",
+			contents:     []string{`{"name":"Pancakes","difficulty":"trivial"}`, `{"name":"Pancakes","difficulty":"easy"}`},
+			want:         recipe{Name: "Pancakes", Difficulty: "easy"},
+			wantRequests: 2,
+			wantFormat:   []bool{true, true},
+			wantFeedback: "value trivial is not one of [easy medium hard]",
+		},
+		{
+			name:         "retries_exhausted",
+			contents:     []string{`I'd rather not.`},
+			opts:         []ChatJSONOption{WithJSONRetries(1)},
+			wantErr:      ErrInvalidJSONResponse,
+			wantRequests: 2,
+			wantFormat:   []bool{true, true},
+		},
+		{
+			name:         "negative_retries",
+			contents:     []string{`I'd rather not.`},
+			opts:         []ChatJSONOption{WithJSONRetries(-1)},
+			wantErr:      ErrInvalidJSONResponse,
+			wantRequests: 1,
+			wantFormat:   []bool{true},
+		},
+		{
+			name:         "format_rejected",
+			contents:     []string{`{"name":"Pancakes","difficulty":"easy"}`},
+			rejectBody:   `{"error":"response_format is not supported"}`,
+			want:         recipe{Name: "Pancakes", Difficulty: "easy"},
+			wantRequests: 2,
+			wantFormat:   []bool{true, false},
+		},
+		{
+			name:         "unrelated_bad_request",
+			contents:     []string{`{"name":"Pancakes","difficulty":"easy"}`},
+			rejectBody:   `{"error":"prompt is too long"}`,
+			wantStatus:   http.StatusBadRequest,
+			wantRequests: 1,
+			wantFormat:   []bool{true},
+		},
+		{
+			name:         "fallback_fails",
+			contents:     []string{`{"name":"Pancakes","difficulty":"easy"}`},
+			rejectBody:   `{"error":"json_schema is not supported"}`,
+			failPlain:    true,
+			wantStatus:   http.StatusBadRequest,
+			wantRequests: 2,
+			wantFormat:   []bool{true, false},
+		},
+		{
+			name:         "without_response_format",
+			contents:     []string{`{"name":"Pancakes","difficulty":"easy"}`},
+			opts:         []ChatJSONOption{WithoutResponseFormat()},
+			want:         recipe{Name: "Pancakes", Difficulty: "easy"},
+			wantRequests: 1,
+			wantFormat:   []bool{false},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			chat := &formatChat{contents: tc.contents, rejectBody: tc.rejectBody, failPlain: tc.failPlain}
+			mux := http.NewServeMux()
+			mux.Handle("/v1/chat/completions", chat)
+			mux.Handle("/", newHandler(mockCatalog(true)))
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+			serviceURL, err := url.Parse(srv.URL)
+			if err != nil {
+				t.Fatalf("failed to parse service URL: %v", err)
+			}
+
+			m := NewManager(WithHTTPClient(srv.Client()))
+			m.serviceURL = serviceURL
+
+			req := ChatRequest{Model: "model-2", Messages: []ChatMessage{UserMessage("Suggest a pancake recipe.")}}
+			got, err := ChatJSON[recipe](t.Context(), m, req, tc.opts...)
+
+			if got, want := len(chat.requests), tc.wantRequests; got != want {
+				t.Fatalf("got %d chat requests, want %d", got, want)
+			}
+			var gotFormat []bool
+			for _, req := range chat.requests {
+				_, ok := req["response_format"]
+				gotFormat = append(gotFormat, ok)
+			}
+			if !reflect.DeepEqual(gotFormat, tc.wantFormat) {
+				t.Errorf("got response_format sent %v, want %v", gotFormat, tc.wantFormat)
+			}
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if tc.wantStatus != 0 {
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != tc.wantStatus {
+					t.Fatalf("got error %v, want APIError with status %d", err, tc.wantStatus)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %+v, want %+v", got, tc.want)
+			}
+
+			// The schema is always sent as a system message, and invalid answers are
+			// fed back with their validation error.
+			messages := chat.requests[len(chat.requests)-1]["messages"].([]any)
+			system := messages[0].(map[string]any)
+			if got, want := system["role"], "system"; got != want {
+				t.Errorf("got first role %v, want %v", got, want)
+			}
+			if got, want := system["content"].(string), `"enum":["easy","medium","hard"]`; !strings.Contains(got, want) {
+				t.Errorf("got instructions %q, want them to contain %q", got, want)
+			}
+			if tc.wantFeedback == "" {
+				return
+			}
+			if got, want := len(messages), 4; got != want {
+				t.Fatalf("got %d messages, want %d", got, want)
+			}
+			if got := messages[3].(map[string]any)["content"].(string); !strings.Contains(got, tc.wantFeedback) {
+				t.Errorf("got feedback %q, want it to contain %q", got, tc.wantFeedback)
+			}
+		})
+	}
+}
+
+// TestChatJSONArray verifies ChatJSON decodes top-level arrays without sending
+// a response_format and rejects types that are not decoded from objects or arrays.
+func TestChatJSONArray(t *testing.T) {
+	chat := &formatChat{contents: []string{`Here are two: [{"name":"Pancakes","difficulty":"easy"},{"name":"Crêpes","difficulty":"medium"}]`}}
+	mux := http.NewServeMux()
+	mux.Handle("/v1/chat/completions", chat)
+	mux.Handle("/", newHandler(mockCatalog(true)))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	serviceURL, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to parse service URL: %v", err)
+	}
+
+	m := NewManager(WithHTTPClient(srv.Client()))
+	m.serviceURL = serviceURL
+	req := ChatRequest{Model: "model-2", Messages: []ChatMessage{UserMessage("Suggest two pancake recipes.")}}
+
+	got, err := ChatJSON[[]recipe](t.Context(), m, req)
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	want := []recipe{{Name: "Pancakes", Difficulty: "easy"}, {Name: "Crêpes", Difficulty: "medium"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if got, want := len(chat.requests), 1; got != want {
+		t.Fatalf("got %d chat requests, want %d", got, want)
+	}
+	if _, ok := chat.requests[0]["response_format"]; ok {
+		t.Error("got response_format sent, want none for an array schema")
+	}
+
+	if _, err := ChatJSON[string](t.Context(), m, req); err == nil {
+		t.Error("got no error for string, want error")
+	}
+	if got, want := len(chat.requests), 1; got != want {
+		t.Errorf("got %d chat requests, want %d", got, want)
+	}
+}
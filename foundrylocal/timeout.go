@@ -0,0 +1,88 @@
+package foundrylocal
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Timeouts configures how long the Manager waits for requests to the Foundry Local
+// service, by class of operation. A timeout covers all attempts of a request,
+// including retries and automatic recovery, as well as reading the response body.
+// Zero values disable the timeout of a class, so that requests are only bounded by
+// the caller's context and the timeout of a client set with WithHTTPClient.
+type Timeouts struct {
+	// Metadata bounds requests that read state from the service, such as listing
+	// catalog, cached, or loaded models and reading the service status.
+	Metadata time.Duration
+	// Download bounds model downloads, including progress reporting.
+	Download time.Duration
+	// Load bounds loading and unloading models.
+	Load time.Duration
+	// Inference bounds chat and text completions, including streamed responses.
+	Inference time.Duration
+}
+
+// DefaultTimeouts returns the Timeouts used by a new Manager: 30 seconds for
+// metadata requests, 2 hours for downloads, and 10 minutes for loading and
+// unloading models as well as for completions.
+//
+// Example:
+//
+//	timeouts := foundrylocal.DefaultTimeouts()
+//	timeouts.Download = 30 * time.Minute
+//	manager := foundrylocal.NewManager(foundrylocal.WithTimeouts(timeouts))
+func DefaultTimeouts() Timeouts {
+	return Timeouts{
+		Metadata:  30 * time.Second,
+		Download:  2 * time.Hour,
+		Load:      10 * time.Minute,
+		Inference: 10 * time.Minute,
+	}
+}
+
+// operationClass groups operations that share a timeout.
+type operationClass int
+
+const (
+	classMetadata operationClass = iota
+	classDownload
+	classLoad
+	classInference
+)
+
+// timeout returns the timeout configured for class.
+func (t Timeouts) timeout(class operationClass) time.Duration {
+	switch class {
+	case classDownload:
+		return t.Download
+	case classLoad:
+		return t.Load
+	case classInference:
+		return t.Inference
+	default:
+		return t.Metadata
+	}
+}
+
+// operationContext derives a context from ctx that is bounded by the Manager's
+// timeout for class. The returned cancel function must always be called.
+func (m *Manager) operationContext(ctx context.Context, class operationClass) (context.Context, context.CancelFunc) {
+	if timeout := m.timeouts.timeout(class); timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// cancelOnClose is a response body that releases the context of its request when
+// it is closed, so that a timeout keeps applying while the body is read.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
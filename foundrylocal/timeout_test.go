@@ -0,0 +1,139 @@
+package foundrylocal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// slowHandler delays responses for path until the request is canceled and serves
+// all other requests with next.
+func slowHandler(path string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == path {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// TestTimeouts verifies that requests are bounded by the timeout of their
+// operation class and by the caller's context.
+func TestTimeouts(t *testing.T) {
+	short := 50 * time.Millisecond
+
+	tests := []struct {
+		name     string
+		slowPath string
+		cached   []string
+		timeouts Timeouts
+		cancel   time.Duration
+		call     func(ctx context.Context, m *Manager) error
+		wantErr  error
+	}{
+		{
+			name:     "metadata_timeout",
+			slowPath: "/foundry/list",
+			cached:   []string{"model-2-npu:2"},
+			timeouts: Timeouts{Metadata: short},
+			call: func(ctx context.Context, m *Manager) error {
+				_, err := m.ListCatalogModels(ctx)
+				return err
+			},
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name:     "load_timeout",
+			slowPath: "/openai/load/model-2-npu:2",
+			cached:   []string{"model-2-npu:2"},
+			timeouts: Timeouts{Metadata: time.Minute, Load: short},
+			call: func(ctx context.Context, m *Manager) error {
+				_, err := m.LoadModel(ctx, "model-2", nil)
+				return err
+			},
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name:     "load_ignores_metadata_timeout",
+			slowPath: "/openai/unload/model-2-npu:2",
+			cached:   []string{"model-2-npu:2"},
+			timeouts: Timeouts{Metadata: short},
+			cancel:   4 * short,
+			call: func(ctx context.Context, m *Manager) error {
+				return m.UnloadModel(ctx, "model-2", nil, false)
+			},
+			wantErr: context.Canceled,
+		},
+		{
+			name:     "caller_canceled",
+			slowPath: "/openai/loadedmodels",
+			cancel:   short,
+			call: func(ctx context.Context, m *Manager) error {
+				_, err := m.ListLoadedModels(ctx)
+				return err
+			},
+			wantErr: context.Canceled,
+		},
+		{
+			name: "body_read_after_return",
+			timeouts: Timeouts{
+				Metadata: time.Minute,
+				Download: time.Minute,
+			},
+			call: func(ctx context.Context, m *Manager) error {
+				_, err := m.DownloadModel(ctx, "model-2", nil)
+				return err
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(slowHandler(tc.slowPath, newHandler(
+				mockCatalog(true),
+				mockLocalModels(tc.cached...),
+				mockJSON("/openai/status", json.RawMessage(`{"modelDirPath": "/models"}`)),
+				mockJSON("/openai/download", json.RawMessage(`{"success": true}`)))))
+			defer srv.Close()
+			serviceURL, err := url.Parse(srv.URL)
+			if err != nil {
+				t.Fatalf("failed to parse service URL: %v", err)
+			}
+
+			m := NewManager(WithEndpoint(serviceURL), WithTimeouts(tc.timeouts), WithHTTPClient(srv.Client()))
+			m.serviceURL = serviceURL
+
+			ctx := t.Context()
+			if tc.cancel > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithCancel(ctx)
+				defer cancel()
+				time.AfterFunc(tc.cancel, cancel)
+			}
+
+			start := time.Now()
+			err = tc.call(ctx, m)
+			if tc.wantErr == nil {
+				if err != nil {
+					t.Fatalf("got error %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tc.wantErr)
+			}
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("got elapsed time %v, want less than 2s", elapsed)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package foundrylocal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ToolChoice controls whether the model calls tools.
+type ToolChoice string
+
+const (
+	// ToolChoiceAuto lets the model decide whether to call tools.
+	ToolChoiceAuto ToolChoice = "auto"
+	// ToolChoiceNone prevents the model from calling tools.
+	ToolChoiceNone ToolChoice = "none"
+	// ToolChoiceRequired forces the model to call at least one tool.
+	ToolChoiceRequired ToolChoice = "required"
+)
+
+// Tool is a tool the model may call.
+type Tool struct {
+	// Type is the type of the tool. Only "function" is supported.
+	Type string `json:"type"`
+	// Function describes the function.
+	Function FunctionDefinition `json:"function"`
+}
+
+// FunctionDefinition describes a function the model may call.
+type FunctionDefinition struct {
+	// Name is the name of the function.
+	Name string `json:"name"`
+	// Description explains what the function does and when to call it.
+	Description string `json:"description,omitempty"`
+	// Parameters describes the arguments of the function as a JSON Schema object.
+	Parameters *JSONSchema `json:"parameters,omitempty"`
+}
+
+// FunctionTool returns a Tool of type "function".
+//
+// Example:
+//
+//	weather := foundrylocal.FunctionTool("get_weather", "Returns the current weather in a city.",
+//		&foundrylocal.JSONSchema{
+//			Type:       "object",
+//			Properties: map[string]*foundrylocal.JSONSchema{"city": {Type: "string"}},
+//			Required:   []string{"city"},
+//		})
+func FunctionTool(name, description string, parameters *JSONSchema) Tool {
+	return Tool{
+		Type: "function",
+		Function: FunctionDefinition{
+			Name:        name,
+			Description: description,
+			Parameters:  parameters,
+		},
+	}
+}
+
+// ToolCall is a call of a tool requested by the model.
+type ToolCall struct {
+	// ID identifies the call. It must be passed to ToolMessage with the result.
+	ID string `json:"id"`
+	// Type is the type of the tool, usually "function".
+	Type string `json:"type"`
+	// Function contains the name and arguments of the called function.
+	Function FunctionCall `json:"function"`
+}
+
+// FunctionCall contains the name and arguments of a called function.
+type FunctionCall struct {
+	// Name is the name of the function.
+	Name string `json:"name"`
+	// Arguments contains the arguments as a JSON object generated by the model.
+	// The arguments may not be valid JSON and should be validated before use.
+	Arguments string `json:"arguments"`
+}
+
+// ToolHandler executes a tool call with the JSON arguments generated by the model
+// and returns the result that is sent back to the model.
+type ToolHandler func(ctx context.Context, arguments json.RawMessage) (string, error)
+
+// ToolRun is the result of RunTools.
+type ToolRun struct {
+	// Response is the final response of the model, which requests no more tool calls.
+	Response ChatResponse
+	// Messages contains the conversation, starting with the messages of the request,
+	// followed by the tool calls, the tool results, and the final answer.
+	Messages []ChatMessage
+	// Rounds is the number of rounds in which tools were called.
+	Rounds int
+}
+
+// RunToolsOption configures RunTools.
+type RunToolsOption func(*runToolsConfig)
+
+type runToolsConfig struct {
+	maxRounds int
+}
+
+// WithMaxToolRounds limits how many rounds of tool calls RunTools executes before
+// it gives up with ErrToolRoundsExceeded. The default is 10 rounds. Values below 1
+// are treated as 1.
+//
+// Example:
+//
+//	run, err := manager.RunTools(ctx, req, handlers, foundrylocal.WithMaxToolRounds(3))
+func WithMaxToolRounds(rounds int) RunToolsOption {
+	return func(cfg *runToolsConfig) {
+		cfg.maxRounds = rounds
+	}
+}
+
+// RunTools sends req and executes the tool calls requested by the model with the
+// matching handler, feeding the results back to the model until it answers without
+// requesting more tool calls. Handlers are called sequentially in the order the
+// calls were requested. If a handler fails or the model calls an unknown tool, the
+// error is reported to the model as the tool result, so it can recover; errors of
+// ctx end the run.
+//
+// RunTools returns ErrToolCallingNotSupported before sending any request if the
+// model does not support tool calling, and an error if a tool in req.Tools has no
+// handler.
+//
+// Example:
+//
+//	req := foundrylocal.ChatRequest{
+//		Model:    "qwen2.5-1.5b",
+//		Messages: []foundrylocal.ChatMessage{foundrylocal.UserMessage("What's the weather in Berlin?")},
+//		Tools:    []foundrylocal.Tool{weather},
+//	}
+//	run, err := manager.RunTools(ctx, req, map[string]foundrylocal.ToolHandler{
+//		"get_weather": func(ctx context.Context, args json.RawMessage) (string, error) {
+//			return `{"temperature": 21, "unit": "celsius"}`, nil
+//		},
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(run.Response.Message().Content)
+func (m *Manager) RunTools(ctx context.Context, req ChatRequest, handlers map[string]ToolHandler, opts ...RunToolsOption) (ToolRun, error) {
+	config := runToolsConfig{
+		maxRounds: 10,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	config.maxRounds = max(config.maxRounds, 1)
+
+	modelInfo, err := m.GetModelInfo(ctx, req.Model, nil)
+	if err != nil {
+		return ToolRun{}, err
+	}
+	if !modelInfo.SupportsToolCalling {
+		return ToolRun{}, fmt.Errorf("%w: %s", ErrToolCallingNotSupported, modelInfo.ID)
+	}
+	for _, tool := range req.Tools {
+		if _, ok := handlers[tool.Function.Name]; !ok {
+			return ToolRun{}, fmt.Errorf("no handler for tool %q", tool.Function.Name)
+		}
+	}
+
+	req.Messages = append([]ChatMessage(nil), req.Messages...)
+	run := ToolRun{}
+	for {
+		resp, err := m.Chat(ctx, req)
+		if err != nil {
+			return ToolRun{}, err
+		}
+		message := resp.Message()
+		req.Messages = append(req.Messages, message)
+		if len(message.ToolCalls) == 0 {
+			run.Response = resp
+			run.Messages = req.Messages
+			return run, nil
+		}
+		if run.Rounds >= config.maxRounds {
+			return ToolRun{}, fmt.Errorf("%w: %d rounds", ErrToolRoundsExceeded, config.maxRounds)
+		}
+
+		run.Rounds++
+		for _, call := range message.ToolCalls {
+			result, err := m.callTool(ctx, call, handlers)
+			if err != nil {
+				return ToolRun{}, err
+			}
+			req.Messages = append(req.Messages, ToolMessage(call.ID, result))
+		}
+	}
+}
+
+// callTool executes call with its handler. Failures are returned as a result for
+// the model; only errors of ctx are returned as error.
+func (m *Manager) callTool(ctx context.Context, call ToolCall, handlers map[string]ToolHandler) (string, error) {
+	handler, ok := handlers[call.Function.Name]
+	if !ok {
+		m.Logger.WarnContext(ctx, "model called unknown tool", "tool", call.Function.Name)
+		return toolError(fmt.Errorf("unknown tool %q", call.Function.Name)), nil
+	}
+
+	m.Logger.DebugContext(ctx, "calling tool", "tool", call.Function.Name, "id", call.ID)
+	result, err := handler(ctx, json.RawMessage(call.Function.Arguments))
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	if err != nil {
+		m.Logger.WarnContext(ctx, "tool call failed", "tool", call.Function.Name, "error", err)
+		return toolError(err), nil
+	}
+	return result, nil
+}
+
+// toolError renders err as a tool result.
+func toolError(err error) string {
+	result, _ := json.Marshal(struct {
+		Error string `json:"error"`
+	}{err.Error()})
+	return string(result)
+}
@@ -0,0 +1,255 @@
+package foundrylocal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// toolCallingCatalog returns a catalog route in which model-2 supports tool calling.
+func toolCallingCatalog() route {
+	catalog := buildCatalog(true)
+	for i := range catalog {
+		if catalog[i].Alias == "model-2" {
+			catalog[i].SupportsToolCalling = true
+		}
+	}
+	data, _ := json.Marshal(catalog)
+	return mockJSON("/foundry/list", data)
+}
+
+// scriptedChat answers chat completion requests with the given responses in
+// order, repeating the last one, and records the decoded requests.
+type scriptedChat struct {
+	mu        sync.Mutex
+	responses []ChatResponse
+	requests  []ChatRequest
+}
+
+func (s *scriptedChat) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	body, _ := io.ReadAll(r.Body)
+	var req ChatRequest
+	json.Unmarshal(body, &req)
+	s.requests = append(s.requests, req)
+	resp := s.responses[min(len(s.requests), len(s.responses))-1]
+	json.NewEncoder(w).Encode(resp)
+}
+
+// toolCallResponse returns a response that requests the given tool calls.
+func toolCallResponse(calls ...ToolCall) ChatResponse {
+	return ChatResponse{Choices: []ChatChoice{{
+		Message:      ChatMessage{Role: RoleAssistant, ToolCalls: calls},
+		FinishReason: FinishReasonToolCalls,
+	}}}
+}
+
+// answerResponse returns a response with a final answer.
+func answerResponse(content string) ChatResponse {
+	return ChatResponse{Choices: []ChatChoice{{Message: AssistantMessage(content), FinishReason: FinishReasonStop}}}
+}
+
+// TestRunTools verifies that RunTools executes requested tool calls and feeds
+// their results back to the model until it answers.
+func TestRunTools(t *testing.T) {
+	weather := FunctionTool("get_weather", "Returns the weather in a city.", &JSONSchema{
+		Type:       "object",
+		Properties: map[string]*JSONSchema{"city": {Type: "string"}},
+		Required:   []string{"city"},
+	})
+	weatherCall := ToolCall{ID: "call-1", Type: "function", Function: FunctionCall{Name: "get_weather", Arguments: `{"city":"Berlin"}`}}
+
+	tests := []struct {
+		name         string
+		model        string
+		tools        []Tool
+		responses    []ChatResponse
+		handlerErr   error
+		opts         []RunToolsOption
+		wantErr      error
+		wantRequests int
+		wantRounds   int
+		wantResult   string
+		wantArgs     string
+	}{
+		{
+			name:         "single_round",
+			model:        "model-2",
+			tools:        []Tool{weather},
+			responses:    []ChatResponse{toolCallResponse(weatherCall), answerResponse("It is sunny.")},
+			wantRequests: 2,
+			wantRounds:   1,
+			wantResult:   `{"temperature":21}`,
+			wantArgs:     `{"city":"Berlin"}`,
+		},
+		{
+			name:         "handler_error",
+			model:        "model-2",
+			tools:        []Tool{weather},
+			responses:    []ChatResponse{toolCallResponse(weatherCall), answerResponse("I don't know.")},
+			handlerErr:   errors.New("service unavailable"),
+			wantRequests: 2,
+			wantRounds:   1,
+			wantResult:   `{"error":"service unavailable"}`,
+			wantArgs:     `{"city":"Berlin"}`,
+		},
+		{
+			name:  "unknown_tool",
+			model: "model-2",
+			tools: []Tool{weather},
+			responses: []ChatResponse{
+				toolCallResponse(ToolCall{ID: "call-1", Type: "function", Function: FunctionCall{Name: "get_time", Arguments: `{}`}}),
+				answerResponse("Sorry."),
+			},
+			wantRequests: 2,
+			wantRounds:   1,
+			wantResult:   `{"error":"unknown tool \"get_time\""}`,
+		},
+		{
+			name:         "rounds_exceeded",
+			model:        "model-2",
+			tools:        []Tool{weather},
+			responses:    []ChatResponse{toolCallResponse(weatherCall)},
+			opts:         []RunToolsOption{WithMaxToolRounds(2)},
+			wantErr:      ErrToolRoundsExceeded,
+			wantRequests: 3,
+		},
+		{
+			name:         "negative_rounds",
+			model:        "model-2",
+			tools:        []Tool{weather},
+			responses:    []ChatResponse{toolCallResponse(weatherCall)},
+			opts:         []RunToolsOption{WithMaxToolRounds(-1)},
+			wantErr:      ErrToolRoundsExceeded,
+			wantRequests: 2,
+		},
+		{
+			name:         "tool_calling_not_supported",
+			model:        "model-1",
+			tools:        []Tool{weather},
+			responses:    []ChatResponse{answerResponse("unused")},
+			wantErr:      ErrToolCallingNotSupported,
+			wantRequests: 0,
+		},
+		{
+			name:         "missing_handler",
+			model:        "model-2",
+			tools:        []Tool{weather, FunctionTool("get_time", "", nil)},
+			responses:    []ChatResponse{answerResponse("unused")},
+			wantErr:      errors.New(`no handler for tool "get_time"`),
+			wantRequests: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			chat := &scriptedChat{responses: tc.responses}
+			mux := http.NewServeMux()
+			mux.Handle("/v1/chat/completions", chat)
+			mux.Handle("/", newHandler(toolCallingCatalog()))
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+			serviceURL, err := url.Parse(srv.URL)
+			if err != nil {
+				t.Fatalf("failed to parse service URL: %v", err)
+			}
+
+			m := NewManager(WithHTTPClient(srv.Client()))
+			m.serviceURL = serviceURL
+
+			var gotArgs string
+			handlers := map[string]ToolHandler{
+				"get_weather": func(ctx context.Context, args json.RawMessage) (string, error) {
+					gotArgs = string(args)
+					return `{"temperature":21}`, tc.handlerErr
+				},
+			}
+			req := ChatRequest{
+				Model:    tc.model,
+				Messages: []ChatMessage{UserMessage("What's the weather in Berlin?")},
+				Tools:    tc.tools,
+			}
+
+			run, err := m.RunTools(t.Context(), req, handlers, tc.opts...)
+			if got, want := len(chat.requests), tc.wantRequests; got != want {
+				t.Errorf("got %d chat requests, want %d", got, want)
+			}
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) && (err == nil || err.Error() != tc.wantErr.Error()) {
+					t.Fatalf("got error %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+
+			if got, want := run.Rounds, tc.wantRounds; got != want {
+				t.Errorf("got %d rounds, want %d", got, want)
+			}
+			if got, want := gotArgs, tc.wantArgs; got != want {
+				t.Errorf("got arguments %q, want %q", got, want)
+			}
+			if got, want := len(run.Messages), 4; got != want {
+				t.Fatalf("got %d messages, want %d", got, want)
+			}
+			if got, want := run.Messages[3], tc.responses[1].Message(); !reflect.DeepEqual(got, want) {
+				t.Errorf("got final message %+v, want %+v", got, want)
+			}
+
+			// The second request contains the tool call and its result.
+			sent := chat.requests[1].Messages
+			if got, want := len(sent), 3; got != want {
+				t.Fatalf("got %d messages in second request, want %d", got, want)
+			}
+			if got, want := sent[1].ToolCalls, tc.responses[0].Message().ToolCalls; !reflect.DeepEqual(got, want) {
+				t.Errorf("got tool calls %+v, want %+v", got, want)
+			}
+			if got, want := sent[2], ToolMessage("call-1", tc.wantResult); !reflect.DeepEqual(got, want) {
+				t.Errorf("got tool message %+v, want %+v", got, want)
+			}
+			if got, want := len(chat.requests[1].Tools), len(tc.tools); got != want {
+				t.Errorf("got %d tools, want %d", got, want)
+			}
+		})
+	}
+}
+
+// TestChatAccumulatorToolCalls verifies that streamed tool call parts are merged.
+func TestChatAccumulatorToolCalls(t *testing.T) {
+	chunks := []ChatChunk{
+		{Choices: []ChatChunkChoice{{Delta: ChatDelta{Role: RoleAssistant, ToolCalls: []ToolCallDelta{
+			{Index: 0, ID: "call-1", Type: "function", Function: FunctionCall{Name: "get_weather", Arguments: `{"ci`}},
+		}}}}},
+		{Choices: []ChatChunkChoice{{Delta: ChatDelta{ToolCalls: []ToolCallDelta{
+			{Index: 0, Function: FunctionCall{Arguments: `ty":"Berlin"}`}},
+			{Index: 1, ID: "call-2", Type: "function", Function: FunctionCall{Name: "get_time", Arguments: `{}`}},
+		}}}}},
+		{Choices: []ChatChunkChoice{{FinishReason: FinishReasonToolCalls}}},
+	}
+
+	var acc ChatAccumulator
+	for _, chunk := range chunks {
+		acc.Add(chunk)
+	}
+
+	want := []ToolCall{
+		{ID: "call-1", Type: "function", Function: FunctionCall{Name: "get_weather", Arguments: `{"city":"Berlin"}`}},
+		{ID: "call-2", Type: "function", Function: FunctionCall{Name: "get_time", Arguments: `{}`}},
+	}
+	resp := acc.Response()
+	if got := resp.Message().ToolCalls; !reflect.DeepEqual(got, want) {
+		t.Errorf("got tool calls %+v, want %+v", got, want)
+	}
+	if got, want := resp.Choices[0].FinishReason, FinishReasonToolCalls; got != want {
+		t.Errorf("got finish reason %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,61 @@
+package foundrylocal
+
+import (
+	"context"
+	"fmt"
+)
+
+// ValidationMode controls how requests to a model are validated against the
+// model's limits, such as ModelInfo.MaxOutputTokens, before they are sent.
+type ValidationMode int
+
+const (
+	// ValidationLenient corrects violations that can be corrected, such as clamping
+	// max_tokens to the model's maximum, and rejects the others with a
+	// ValidationError. This is the default.
+	ValidationLenient ValidationMode = iota
+	// ValidationStrict rejects all violations with a ValidationError.
+	ValidationStrict
+	// ValidationDisabled sends requests without validating them.
+	ValidationDisabled
+)
+
+// validateRequest checks the limits of a request to the model described by info
+// and returns the max_tokens value to send. hasTools reports whether the request
+// contains tools.
+func (m *Manager) validateRequest(ctx context.Context, op string, info ModelInfo, maxTokens *int, hasTools bool) (*int, error) {
+	if m.validationMode == ValidationDisabled {
+		return maxTokens, nil
+	}
+
+	var violations []Violation
+	switch {
+	case maxTokens == nil:
+	case *maxTokens < 1:
+		violations = append(violations, Violation{
+			Field:   "max_tokens",
+			Message: fmt.Sprintf("%d is not positive", *maxTokens),
+		})
+	case info.MaxOutputTokens > 0 && *maxTokens > info.MaxOutputTokens && m.validationMode == ValidationLenient:
+		m.Logger.WarnContext(ctx, "clamping max_tokens to model limit",
+			"modelID", info.ID, "maxTokens", *maxTokens, "maxOutputTokens", info.MaxOutputTokens)
+		maxTokens = Ptr(info.MaxOutputTokens)
+	case info.MaxOutputTokens > 0 && *maxTokens > info.MaxOutputTokens:
+		violations = append(violations, Violation{
+			Field:   "max_tokens",
+			Message: fmt.Sprintf("%d exceeds the model's maximum of %d output tokens", *maxTokens, info.MaxOutputTokens),
+		})
+	}
+	if hasTools && !info.SupportsToolCalling {
+		violations = append(violations, Violation{
+			Field:   "tools",
+			Message: "model does not support tool calling",
+			Err:     ErrToolCallingNotSupported,
+		})
+	}
+
+	if len(violations) > 0 {
+		return nil, &ValidationError{Op: op, Model: info.ID, Violations: violations}
+	}
+	return maxTokens, nil
+}
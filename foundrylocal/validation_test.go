@@ -0,0 +1,116 @@
+package foundrylocal
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestValidateRequest verifies that requests exceeding model limits are clamped or
+// rejected before they are sent, depending on the validation mode.
+func TestValidateRequest(t *testing.T) {
+	const response = `{"choices":[{"index":0,"message":{"role":"assistant","content":"Autumn"},"finish_reason":"stop"}]}`
+	weather := FunctionTool("get_weather", "", nil)
+
+	tests := []struct {
+		name           string
+		mode           ValidationMode
+		maxTokens      *int
+		tools          []Tool
+		wantViolations []Violation
+		wantMaxTokens  any
+	}{
+		{
+			name:          "within_limits",
+			maxTokens:     Ptr(512),
+			wantMaxTokens: 512.0,
+		},
+		{
+			name:          "lenient_clamps",
+			maxTokens:     Ptr(4096),
+			wantMaxTokens: 1024.0,
+		},
+		{
+			name:      "strict_rejects",
+			mode:      ValidationStrict,
+			maxTokens: Ptr(4096),
+			wantViolations: []Violation{
+				{Field: "max_tokens", Message: "4096 exceeds the model's maximum of 1024 output tokens"},
+			},
+		},
+		{
+			name:      "all_violations",
+			maxTokens: Ptr(0),
+			tools:     []Tool{weather},
+			wantViolations: []Violation{
+				{Field: "max_tokens", Message: "0 is not positive"},
+				{Field: "tools", Message: "model does not support tool calling", Err: ErrToolCallingNotSupported},
+			},
+		},
+		{
+			name:          "disabled",
+			mode:          ValidationDisabled,
+			maxTokens:     Ptr(4096),
+			tools:         []Tool{weather},
+			wantMaxTokens: 4096.0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				body   map[string]any
+				header http.Header
+			)
+			srv := httptest.NewServer(mockChat(t, http.StatusOK, response, &body, &header))
+			defer srv.Close()
+			serviceURL, err := url.Parse(srv.URL)
+			if err != nil {
+				t.Fatalf("failed to parse service URL: %v", err)
+			}
+
+			m := NewManager(WithHTTPClient(srv.Client()), WithValidationMode(tc.mode))
+			m.serviceURL = serviceURL
+
+			req := ChatRequest{
+				Model:     "model-2",
+				Messages:  []ChatMessage{UserMessage("Write me a haiku")},
+				MaxTokens: tc.maxTokens,
+				Tools:     tc.tools,
+			}
+			_, err = m.Chat(t.Context(), req)
+
+			if tc.wantViolations != nil {
+				var validationErr *ValidationError
+				if !errors.As(err, &validationErr) {
+					t.Fatalf("got error %v, want ValidationError", err)
+				}
+				if got, want := validationErr.Violations, tc.wantViolations; !reflect.DeepEqual(got, want) {
+					t.Errorf("got violations %+v, want %+v", got, want)
+				}
+				if got, want := errors.Is(err, ErrToolCallingNotSupported), len(tc.tools) > 0; got != want {
+					t.Errorf("got errors.Is(err, ErrToolCallingNotSupported) %t, want %t", got, want)
+				}
+				if got, want := validationErr.Model, "model-2-npu:2"; got != want {
+					t.Errorf("got model %q, want %q", got, want)
+				}
+				if body != nil {
+					t.Errorf("got request %v, want none", body)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if got, want := body["max_tokens"], tc.wantMaxTokens; got != want {
+				t.Errorf("got max_tokens %v, want %v", got, want)
+			}
+			if got, want := *req.MaxTokens, *tc.maxTokens; got != want {
+				t.Errorf("caller's max_tokens changed to %d, want %d", got, want)
+			}
+		})
+	}
+}
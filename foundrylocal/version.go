@@ -0,0 +1,97 @@
+package foundrylocal
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MinRuntimeVersion is the oldest Foundry Local runtime version supported by this module.
+// The Manager refuses to start or attach to older runtimes with ErrUnsupportedRuntime,
+// unless it is attached with WithEndpoint, where the version is not detected.
+const MinRuntimeVersion = "0.7.117"
+
+// RuntimeVersion returns the version of the running Foundry Local runtime, such as "0.8.117".
+// The service is started if necessary. The version is detected with the ServiceLauncher
+// and cached until StopService is called. RuntimeVersion returns an empty string if the
+// version cannot be determined, for example when the Manager is attached to a service
+// with WithEndpoint or the ServiceLauncher does not implement CommandRunner.
+//
+// Example:
+//
+//	version, err := manager.RuntimeVersion(ctx)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Printf("Foundry Local runtime version: %s\n", version)
+func (m *Manager) RuntimeVersion(ctx context.Context) (string, error) {
+	if err := m.StartService(ctx); err != nil {
+		return "", err
+	}
+	return m.runtimeVersion, nil
+}
+
+// checkRuntimeVersion returns ErrUnsupportedRuntime if version is older than MinRuntimeVersion.
+// An empty version is accepted since it cannot be verified.
+func checkRuntimeVersion(version string) error {
+	if version == "" {
+		return nil
+	}
+	if compareVersions(version, MinRuntimeVersion) < 0 {
+		return fmt.Errorf("%w: version %s is older than %s", ErrUnsupportedRuntime, version, MinRuntimeVersion)
+	}
+	return nil
+}
+
+// checkModelCompatibility returns ErrModelIncompatible if modelInfo requires a newer
+// runtime than the one the Manager is connected to. Models are accepted if either
+// version is unknown.
+func (m *Manager) checkModelCompatibility(modelInfo ModelInfo) error {
+	if m.runtimeVersion == "" || modelInfo.MinFLVersion == "" {
+		return nil
+	}
+	if compareVersions(modelInfo.MinFLVersion, m.runtimeVersion) > 0 {
+		return fmt.Errorf("%w: model %s requires Foundry Local %s, running %s",
+			ErrModelIncompatible, modelInfo.ID, modelInfo.MinFLVersion, m.runtimeVersion)
+	}
+	return nil
+}
+
+// compareVersions compares two dotted version strings such as "0.8.117" numerically.
+// Build metadata and pre-release suffixes ("+abc", "-beta") are ignored, and missing
+// or non-numeric components are treated as 0. The result is -1 if a < b, 0 if a == b,
+// and +1 if a > b.
+func compareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := range max(len(pa), len(pb)) {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionParts splits a version string into its numeric components.
+func versionParts(v string) []int {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "+-"); i >= 0 {
+		v = v[:i]
+	}
+	fields := strings.Split(v, ".")
+	parts := make([]int, len(fields))
+	for i, f := range fields {
+		parts[i], _ = strconv.Atoi(f)
+	}
+	return parts
+}
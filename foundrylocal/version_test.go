@@ -0,0 +1,113 @@
+package foundrylocal
+
+import (
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// TestCompareVersions verifies numeric comparison of dotted runtime versions.
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "0.8.117", b: "0.8.117", want: 0},
+		{a: "0.8.117+67073234e7", b: "0.8.117", want: 0},
+		{a: "0.8.9", b: "0.8.117", want: -1},
+		{a: "0.10.0", b: "0.8.117", want: 1},
+		{a: "1.0", b: "1.0.0", want: 0},
+		{a: "v1.0.1", b: "1.0.0", want: 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.a+"_"+tc.b, func(t *testing.T) {
+			if got, want := compareVersions(tc.a, tc.b), tc.want; got != want {
+				t.Errorf("got %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+// TestStartServiceRuntimeVersion verifies StartService detects the runtime
+// version and refuses runtimes older than MinRuntimeVersion.
+func TestStartServiceRuntimeVersion(t *testing.T) {
+	const running = "Model management service is running on http://127.0.0.1:5273/openai/status\n"
+	const notRunning = "Model management service is not running!\n"
+
+	tests := []struct {
+		name      string
+		status    string
+		version   string
+		err       error
+		wantStops int
+	}{
+		{
+			name:    "supported_runtime",
+			status:  running,
+			version: "0.8.117+67073234e7\n",
+			err:     nil,
+		},
+		{
+			name:    "unsupported_runtime",
+			status:  running,
+			version: "0.6.87\n",
+			err:     ErrUnsupportedRuntime,
+		},
+		{
+			name:      "unsupported_runtime_started",
+			status:    notRunning,
+			version:   "0.6.87\n",
+			err:       ErrUnsupportedRuntime,
+			wantStops: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			launcher := &fakeRunner{
+				fakeLauncher: fakeLauncher{status: tc.status, startStatus: running},
+				outputs:      map[string]string{"--version": tc.version},
+			}
+			m := NewManager(WithServiceLauncher(launcher))
+
+			err := m.StartService(t.Context())
+			if got, want := err, tc.err; !errors.Is(got, want) {
+				t.Fatalf("got error %v, want %v", got, want)
+			}
+			if got, want := m.IsServiceRunning(), tc.err == nil; got != want {
+				t.Errorf("got service running %t, want %t", got, want)
+			}
+			// A service this Manager started for an unsupported runtime is stopped
+			// right away; a service started by someone else is left alone.
+			if got, want := launcher.stops, tc.wantStops; got != want {
+				t.Errorf("got %d stops, want %d", got, want)
+			}
+		})
+	}
+}
+
+// TestLoadModelIncompatible ensures DownloadModel and LoadModel reject models
+// requiring a newer runtime than the one that is running.
+func TestLoadModelIncompatible(t *testing.T) {
+	srv := httptest.NewServer(newHandler(
+		mockCatalog(true),
+		mockLocalModels("model-2-npu:2")))
+	defer srv.Close()
+	serviceURL, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to parse service URL: %v", err)
+	}
+
+	m := NewManager(WithHTTPClient(srv.Client()))
+	m.serviceURL = serviceURL
+	// The test catalog requires Foundry Local 1.0.0.
+	m.runtimeVersion = "0.8.117"
+
+	if _, err := m.DownloadModel(t.Context(), "model-2", nil); !errors.Is(err, ErrModelIncompatible) {
+		t.Errorf("got download error %v, want %v", err, ErrModelIncompatible)
+	}
+	if _, err := m.LoadModel(t.Context(), "model-2", nil); !errors.Is(err, ErrModelIncompatible) {
+		t.Errorf("got load error %v, want %v", err, ErrModelIncompatible)
+	}
+}
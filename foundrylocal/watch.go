@@ -0,0 +1,215 @@
+package foundrylocal
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+// ServiceEventType identifies the kind of change reported by Watch.
+type ServiceEventType int
+
+const (
+	// EventServiceUp indicates that the service is running and reachable.
+	EventServiceUp ServiceEventType = iota + 1
+	// EventServiceDown indicates that the service is not running or not reachable.
+	EventServiceDown
+	// EventEndpointChanged indicates that the service is now listening on a different endpoint.
+	EventEndpointChanged
+	// EventModelLoaded indicates that a model was loaded into memory.
+	EventModelLoaded
+	// EventModelUnloaded indicates that a model was removed from memory.
+	EventModelUnloaded
+)
+
+// String returns the name of the event type.
+func (t ServiceEventType) String() string {
+	switch t {
+	case EventServiceUp:
+		return "ServiceUp"
+	case EventServiceDown:
+		return "ServiceDown"
+	case EventEndpointChanged:
+		return "EndpointChanged"
+	case EventModelLoaded:
+		return "ModelLoaded"
+	case EventModelUnloaded:
+		return "ModelUnloaded"
+	default:
+		return "Unknown"
+	}
+}
+
+// ServiceEvent describes a change of the Foundry Local service or its loaded models.
+type ServiceEvent struct {
+	// Type is the kind of change.
+	Type ServiceEventType
+	// Status is the service status observed when the event was detected.
+	// It is the zero value if the service is down.
+	Status ServiceStatus
+	// ModelID is the ID of the loaded or unloaded model for model events.
+	ModelID string
+	// Err is the error that caused an EventServiceDown event.
+	Err error
+	// Time is the time the change was detected.
+	Time time.Time
+}
+
+// defaultPollInterval is how often Watch polls the service unless WithPollInterval is used.
+const defaultPollInterval = 5 * time.Second
+
+// WatchOption configures Watch.
+type WatchOption func(*watchConfig)
+
+type watchConfig struct {
+	interval time.Duration
+}
+
+// WithPollInterval sets how often Watch polls the service.
+// The default interval is 5 seconds, which is also used if interval is not positive.
+//
+// Example:
+//
+//	events := manager.Watch(ctx, foundrylocal.WithPollInterval(time.Second))
+func WithPollInterval(interval time.Duration) WatchOption {
+	return func(cfg *watchConfig) {
+		cfg.interval = interval
+	}
+}
+
+// Watch polls the Foundry Local service in the background and reports changes on
+// the returned channel. The first poll reports the current state: EventServiceUp
+// followed by EventModelLoaded for every loaded model, or EventServiceDown. Later
+// polls report service availability changes, endpoint changes, and models that
+// were loaded or unloaded, for example by other applications. Any status failure
+// other than ErrFoundryNotInstalled is reported as EventServiceDown. When the
+// service goes down, EventModelUnloaded is reported for all models that were loaded.
+//
+// Watch uses the same plumbing as Status and does not start the service. The
+// channel is closed when ctx is done.
+//
+// Example:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	defer cancel()
+//
+//	for event := range manager.Watch(ctx) {
+//		switch event.Type {
+//		case foundrylocal.EventServiceDown:
+//			log.Printf("Foundry Local is down: %v", event.Err)
+//		case foundrylocal.EventModelLoaded, foundrylocal.EventModelUnloaded:
+//			log.Printf("%s: %s", event.Type, event.ModelID)
+//		}
+//	}
+func (m *Manager) Watch(ctx context.Context, opts ...WatchOption) <-chan ServiceEvent {
+	config := watchConfig{
+		interval: defaultPollInterval,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if config.interval <= 0 {
+		config.interval = defaultPollInterval
+	}
+
+	events := make(chan ServiceEvent)
+	go func() {
+		defer close(events)
+
+		var (
+			polled bool
+			up     bool
+			last   ServiceStatus
+		)
+		ticker := time.NewTicker(config.interval)
+		defer ticker.Stop()
+
+		for {
+			status, err := m.pollStatus(ctx)
+			switch {
+			case ctx.Err() != nil:
+				return
+			case errors.Is(err, ErrFoundryNotInstalled):
+				m.Logger.DebugContext(ctx, "failed to poll Foundry service status", "error", err)
+			default:
+				for _, event := range diffStatus(polled, up, last, status, err) {
+					select {
+					case events <- event:
+					case <-ctx.Done():
+						return
+					}
+				}
+				polled, up, last = true, err == nil, status
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events
+}
+
+// pollStatus reads the service status like Status, but does not detect the runtime version.
+func (m *Manager) pollStatus(ctx context.Context) (ServiceStatus, error) {
+	endpoint := m.endpoint
+	if endpoint == nil {
+		var err error
+		if endpoint, err = m.statusEndpoint(ctx); err != nil {
+			return ServiceStatus{}, err
+		}
+	}
+	ctx, cancel := m.operationContext(ctx, classMetadata)
+	defer cancel()
+	return readStatus(ctx, m.client, endpoint)
+}
+
+// diffStatus computes the events describing the transition from the previous poll
+// (wasUp, last) to the current poll (status, err). If no poll has happened yet,
+// the current state is reported in full.
+func diffStatus(polled, wasUp bool, last, status ServiceStatus, err error) []ServiceEvent {
+	now := time.Now()
+	var events []ServiceEvent
+	isUp := err == nil
+
+	switch {
+	case isUp && (!polled || !wasUp):
+		events = append(events, ServiceEvent{Type: EventServiceUp, Status: status, Time: now})
+	case isUp && last.Endpoint.String() != status.Endpoint.String():
+		events = append(events, ServiceEvent{Type: EventEndpointChanged, Status: status, Time: now})
+	case !isUp && (!polled || wasUp):
+		events = append(events, ServiceEvent{Type: EventServiceDown, Err: err, Time: now})
+	}
+
+	var loaded []string
+	if isUp {
+		loaded = status.LoadedModels
+	}
+	var previous []string
+	if wasUp {
+		previous = last.LoadedModels
+	}
+
+	for _, id := range sortedDifference(previous, loaded) {
+		events = append(events, ServiceEvent{Type: EventModelUnloaded, Status: status, ModelID: id, Time: now})
+	}
+	for _, id := range sortedDifference(loaded, previous) {
+		events = append(events, ServiceEvent{Type: EventModelLoaded, Status: status, ModelID: id, Time: now})
+	}
+	return events
+}
+
+// sortedDifference returns the sorted elements of a that are not in b.
+func sortedDifference(a, b []string) []string {
+	var diff []string
+	for _, s := range a {
+		if !slices.Contains(b, s) {
+			diff = append(diff, s)
+		}
+	}
+	slices.Sort(diff)
+	return diff
+}
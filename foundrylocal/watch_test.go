@@ -0,0 +1,127 @@
+package foundrylocal
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestWatch verifies Watch reports the initial state, model changes, and the
+// service going down as a sequence of events.
+func TestWatch(t *testing.T) {
+	var mu sync.Mutex
+	loaded := []string{"model-1"}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/openai/status":
+			w.Write([]byte(`{"modelDirPath": "/models"}`))
+		case "/openai/loadedmodels":
+			json.NewEncoder(w).Encode(loaded)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	launcher := &fakeLauncher{
+		status: "Model management service is running on " + srv.URL + "/openai/status\n",
+	}
+	m := NewManager(WithServiceLauncher(launcher))
+	events := m.Watch(t.Context(), WithPollInterval(10*time.Millisecond))
+
+	next := func() ServiceEvent {
+		t.Helper()
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatal("event channel closed unexpectedly")
+			}
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for event")
+		}
+		return ServiceEvent{}
+	}
+
+	want := []struct {
+		typ     ServiceEventType
+		modelID string
+		change  func()
+	}{
+		{typ: EventServiceUp},
+		{typ: EventModelLoaded, modelID: "model-1", change: func() {
+			mu.Lock()
+			loaded = []string{"model-2"}
+			mu.Unlock()
+		}},
+		{typ: EventModelUnloaded, modelID: "model-1"},
+		{typ: EventModelLoaded, modelID: "model-2", change: func() {
+			launcher.setStatus("Model management service is not running!\n")
+		}},
+		{typ: EventServiceDown},
+		{typ: EventModelUnloaded, modelID: "model-2"},
+	}
+
+	for i, w := range want {
+		event := next()
+		if got, want := event.Type, w.typ; got != want {
+			t.Fatalf("event %d: got type %s, want %s", i, got, want)
+		}
+		if got, want := event.ModelID, w.modelID; got != want {
+			t.Errorf("event %d: got model ID %q, want %q", i, got, want)
+		}
+		if w.change != nil {
+			w.change()
+		}
+	}
+}
+
+// TestWatchStatusError verifies Watch reports a failing status resource as
+// EventServiceDown instead of dropping the poll.
+func TestWatchStatusError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	launcher := &fakeLauncher{
+		status: "Model management service is running on " + srv.URL + "/openai/status\n",
+	}
+	m := NewManager(WithServiceLauncher(launcher))
+
+	select {
+	case event := <-m.Watch(t.Context(), WithPollInterval(10*time.Millisecond)):
+		if got, want := event.Type, EventServiceDown; got != want {
+			t.Fatalf("got type %s, want %s", got, want)
+		}
+		if event.Err == nil || errors.Is(event.Err, ErrServiceNotRunning) {
+			t.Errorf("got error %v, want status failure", event.Err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+}
+
+// TestWatchInvalidInterval verifies Watch falls back to the default poll interval
+// instead of panicking if the interval is not positive.
+func TestWatchInvalidInterval(t *testing.T) {
+	launcher := &fakeLauncher{status: "Model management service is not running!\n"}
+	m := NewManager(WithServiceLauncher(launcher))
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		select {
+		case event := <-m.Watch(t.Context(), WithPollInterval(interval)):
+			if got, want := event.Type, EventServiceDown; got != want {
+				t.Errorf("interval %v: got type %s, want %s", interval, got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("interval %v: timed out waiting for event", interval)
+		}
+	}
+}